@@ -1,42 +1,24 @@
 package validator
 
 import (
-	"errors"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
-	"strconv"
+	"golang.org/x/text/language"
 )
 
 type AnyValue[T any] struct {
-	err     error
-	key     string
-	val     T
-	mark    bool
-	conf    *config
-	locConf *i18n.LocalizeConfig
+	err error
+	core
+	val T
 }
 
 func Any[T any](k string, v T) *AnyValue[T] {
 	return &AnyValue[T]{
-		key:  k,
+		core: newCore(k),
 		val:  v,
-		conf: _conf,
 	}
 }
 
 func (c *AnyValue[T]) validate(messageId string, ok bool, args ...any) *AnyValue[T] {
-	if c.mark || ok {
-		return c
-	}
-	c.mark = true
-	td := map[string]any{"Key": c.key}
-	for i, v := range args {
-		key := "Arg" + strconv.Itoa(i)
-		td[key] = v
-	}
-	c.locConf = &i18n.LocalizeConfig{
-		MessageID:    messageId,
-		TemplateData: td,
-	}
+	c.check(messageId, ok, args...)
 	return c
 }
 
@@ -48,20 +30,87 @@ func (c *AnyValue[T]) Err() error {
 	if c.err != nil {
 		return c.err
 	}
-	str, err := c.conf.loc.Localize(c.locConf)
-	if err != nil {
-		c.err = err
-		return c.err
-	}
-
-	c.err = errors.New(str)
+	c.err = c.fieldError(c.val)
 	return c.err
 }
 
-func (c *AnyValue[T]) setConf(conf *config) {
-	c.conf = conf
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *AnyValue[T]) All() *AnyValue[T] {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *AnyValue[T]) Label(id string) *AnyValue[T] {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *AnyValue[T]) Msg(text string) *AnyValue[T] {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *AnyValue[T]) Msgs(texts map[language.Tag]string) *AnyValue[T] {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *AnyValue[T]) MsgID(id string) *AnyValue[T] {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *AnyValue[T]) When(cond bool) *AnyValue[T] {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *AnyValue[T]) Unless(cond bool) *AnyValue[T] {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *AnyValue[T]) Optional() *AnyValue[T] {
+	c.setOptional(!isPresent(c.val))
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *AnyValue[T]) RequiredIf(other string, otherVal, want any) *AnyValue[T] {
+	c.requiredIf(isPresent(c.val), other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *AnyValue[T]) RequiredWith(other string, otherVal any) *AnyValue[T] {
+	c.requiredWith(isPresent(c.val), other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *AnyValue[T]) RequiredWithout(other string, otherVal any) *AnyValue[T] {
+	c.requiredWithout(isPresent(c.val), other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *AnyValue[T]) ExcludedWith(other string, otherVal any) *AnyValue[T] {
+	c.excludedWith(isPresent(c.val), other, otherVal)
+	return c
 }
 
 func (c *AnyValue[T]) Customize(messageId string, f func(T) bool) *AnyValue[T] {
-	return c.validate(messageId, f(c.val), nil)
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
 }
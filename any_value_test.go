@@ -84,3 +84,9 @@ func TestAnyValue_Customize(t *testing.T) {
 		assert.Equal(t, err.Error(), "Name 不能为空")
 	})
 }
+
+func TestAnyValue_Optional(t *testing.T) {
+	var f = func(v []int) bool { return len(v) > 1 }
+	assert.Nil(t, Any("ids", []int{}).Optional().Customize("AnyValue.Customize", f).Err())
+	assert.Error(t, Any("ids", []int{1}).Optional().Customize("AnyValue.Customize", f).Err())
+}
@@ -0,0 +1,595 @@
+// Command validator-gen generates type-safe Validate methods from validate struct tags.
+//
+// Add a directive to the file declaring the request types:
+//
+//	//go:generate go run github.com/xray-family/validator/cmd/validator-gen
+//
+// For every struct with at least one validate tag, it writes
+//
+//	func (c *Req) Validate(r *http.Request) error
+//
+// into <file>_validator.go, calling validator.String, validator.Ordered, validator.Slice, validator.SliceOf
+// validator.Pointer, validator.PointerString and validator.PointerOrdered directly. The tag syntax is the same as validator.Struct. Unknown rules, rules applied to the wrong kind
+// and malformed arguments, such as an integer out of the range of the field or an invalid regular expression, fail at generate time.
+//
+// Like validator.Struct, struct fields, pointers to struct and slices of struct declared in the same package are
+// validated recursively with validator.Nested and validator.Each, embedded structs are flattened and the fields are
+// checked in declaration order. Recursive types are rejected.
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/xray-family/validator/internal/tags"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"log"
+	"math"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const header = "// Code generated by validator-gen. DO NOT EDIT."
+
+func main() {
+	log.SetFlags(0)
+	log.SetPrefix("validator-gen: ")
+	var output = flag.String("output", "", "output file name; default <file>_validator.go")
+	var types = flag.String("type", "", "comma-separated list of struct names; default every struct with validate tags")
+	flag.Parse()
+
+	var filename = flag.Arg(0)
+	if filename == "" {
+		filename = os.Getenv("GOFILE")
+	}
+	if filename == "" {
+		log.Fatal("usage: validator-gen [-type T1,T2] [-output file] file.go")
+	}
+	var names []string
+	if *types != "" {
+		names = strings.Split(*types, ",")
+	}
+	buf, err := generate(filename, names)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if *output == "" {
+		*output = strings.TrimSuffix(filename, ".go") + "_validator.go"
+	}
+	if err := os.WriteFile(*output, buf, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// kindInfo 字段类型信息
+type kindInfo struct {
+	kind     tags.Kind
+	integer  bool      // 整数类型
+	unsigned bool      // 无符号整数
+	bits     int       // 数值的位数, 用于检查参数范围
+	array    bool      // 数组, 需要切片后传入
+	elem     *kindInfo // 切片元素或指针指向的类型
+}
+
+// numbers 数值类型的位数及符号
+var numbers = map[string]kindInfo{
+	"int":     {kind: tags.Number, integer: true, bits: 64},
+	"int8":    {kind: tags.Number, integer: true, bits: 8},
+	"int16":   {kind: tags.Number, integer: true, bits: 16},
+	"int32":   {kind: tags.Number, integer: true, bits: 32},
+	"rune":    {kind: tags.Number, integer: true, bits: 32},
+	"int64":   {kind: tags.Number, integer: true, bits: 64},
+	"uint":    {kind: tags.Number, integer: true, unsigned: true, bits: 64},
+	"uint8":   {kind: tags.Number, integer: true, unsigned: true, bits: 8},
+	"byte":    {kind: tags.Number, integer: true, unsigned: true, bits: 8},
+	"uint16":  {kind: tags.Number, integer: true, unsigned: true, bits: 16},
+	"uint32":  {kind: tags.Number, integer: true, unsigned: true, bits: 32},
+	"uint64":  {kind: tags.Number, integer: true, unsigned: true, bits: 64},
+	"uintptr": {kind: tags.Number, integer: true, unsigned: true, bits: 64},
+	"float32": {kind: tags.Number, bits: 32},
+	"float64": {kind: tags.Number, bits: 64},
+}
+
+type generator struct {
+	fset     *token.FileSet
+	types    map[string]ast.Expr // 包内类型声明
+	visiting map[string]bool     // 正在生成的结构体, 用于发现递归类型
+	buf      bytes.Buffer
+}
+
+// generate 为文件中带有 validate 标签的结构体生成 Validate 方法
+func generate(filename string, names []string) ([]byte, error) {
+	var g = &generator{fset: token.NewFileSet(), types: map[string]ast.Expr{}}
+	file, err := parser.ParseFile(g.fset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if err := g.loadPackage(filepath.Dir(filename), file.Name.Name); err != nil {
+		return nil, err
+	}
+
+	var structs []*ast.TypeSpec
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok || gd.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts := spec.(*ast.TypeSpec)
+			if st, ok := ts.Type.(*ast.StructType); ok && (contains(names, ts.Name.Name) || len(names) == 0 && g.hasTag(st, map[*ast.StructType]bool{})) {
+				structs = append(structs, ts)
+			}
+		}
+	}
+	if len(structs) == 0 {
+		return nil, fmt.Errorf("%s: no struct with validate tags found", filename)
+	}
+
+	fmt.Fprintf(&g.buf, "%s\n\npackage %s\n\n", header, file.Name.Name)
+	fmt.Fprintf(&g.buf, "import (\n\t\"github.com/xray-family/validator\"\n\t\"net/http\"\n)\n")
+	for _, ts := range structs {
+		if err := g.genStruct(ts); err != nil {
+			return nil, err
+		}
+	}
+	return format.Source(g.buf.Bytes())
+}
+
+// loadPackage 收集包内的类型声明, 用于解析自定义类型
+func (c *generator) loadPackage(dir string, pkg string) error {
+	matches, err := filepath.Glob(filepath.Join(dir, "*.go"))
+	if err != nil {
+		return err
+	}
+	sort.Strings(matches)
+	for _, item := range matches {
+		if strings.HasSuffix(item, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(c.fset, item, nil, 0)
+		if err != nil {
+			return err
+		}
+		if file.Name.Name != pkg {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if gd, ok := decl.(*ast.GenDecl); ok && gd.Tok == token.TYPE {
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					c.types[ts.Name.Name] = ts.Type
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *generator) genStruct(ts *ast.TypeSpec) error {
+	var values []string
+	c.visiting = map[string]bool{ts.Name.Name: true}
+	if err := c.genFields(ts.Type.(*ast.StructType), "c", &values); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&c.buf, "\nfunc (c *%s) Validate(r *http.Request) error {\n", ts.Name.Name)
+	fmt.Fprintf(&c.buf, "return validator.NewValidator(r).Validate(%s)\n}\n", joinValues(values))
+	return nil
+}
+
+// genFields 按声明顺序生成字段的校验器, recv 为结构体的表达式
+func (c *generator) genFields(st *ast.StructType, recv string, values *[]string) error {
+	for _, field := range st.Fields.List {
+		var tag reflect.StructTag
+		if field.Tag != nil {
+			s, _ := strconv.Unquote(field.Tag.Value)
+			tag = reflect.StructTag(s)
+		}
+
+		// 与 validator.Struct 一样, 嵌入的结构体的字段被提升, 其他嵌入字段以类型名作为字段名
+		var names = field.Names
+		if len(names) == 0 {
+			if embed, name := c.structOf(field.Type); embed != nil {
+				if err := c.genStructFields(embed, name, recv, values); err != nil {
+					return err
+				}
+				continue
+			}
+			ident, err := embeddedName(field.Type)
+			if err != nil {
+				return fmt.Errorf("%s: %w", c.fset.Position(field.Pos()), err)
+			}
+			names = []*ast.Ident{ident}
+		}
+
+		var rawTag = tag.Get("validate")
+		if rawTag == "-" {
+			continue
+		}
+		for _, ident := range names {
+			if !ident.IsExported() {
+				continue
+			}
+			if err := c.genField(field, recv, ident.Name, tag, rawTag, values); err != nil {
+				return fmt.Errorf("%s: field %s: %w", c.fset.Position(field.Pos()), ident.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// embeddedName 嵌入字段的字段名, 只支持包内的类型及其指针
+func embeddedName(expr ast.Expr) (*ast.Ident, error) {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		return t, nil
+	case *ast.StarExpr:
+		if ident, ok := t.X.(*ast.Ident); ok {
+			return ident, nil
+		}
+	}
+	return nil, fmt.Errorf("embedded field %s is not supported", types.ExprString(expr))
+}
+
+func (c *generator) genField(field *ast.Field, recv, name string, tag reflect.StructTag, rawTag string, values *[]string) error {
+	var key, _, _ = strings.Cut(tag.Get("json"), ",")
+	if key == "" || key == "-" {
+		key = name
+	}
+	var path = recv + "." + name
+	var rules []tags.Rule
+	if rawTag != "" {
+		list, err := tags.Parse(rawTag)
+		if err != nil {
+			return err
+		}
+		rules = list
+	}
+
+	// 结构体及其指针, 切片与 validator.Struct 一样递归校验
+	if ok, err := c.genNested(field.Type, key, path, rules, values); ok || err != nil {
+		return err
+	}
+	if len(rules) == 0 {
+		return nil
+	}
+	info, err := c.kindOf(field.Type, 0)
+	if err != nil {
+		return err
+	}
+
+	if info.kind != tags.Pointer {
+		expr, err := c.genValue(info, key, path, rules)
+		if err != nil {
+			return err
+		}
+		*values = append(*values, expr)
+		return nil
+	}
+
+	var rest []tags.Rule
+	for _, r := range rules {
+		if _, _, err := tags.Lookup(tags.Pointer, r); err != nil {
+			rest = append(rest, r)
+			continue
+		}
+		*values = append(*values, fmt.Sprintf("validator.Pointer(%q, %s).Required()", key, path))
+	}
+	if len(rest) == 0 {
+		return nil
+	}
+	if info.elem.kind == tags.Pointer {
+		return errors.New("pointer to pointer is not supported")
+	}
+	// 字符串及数值的指针为 nil 时跳过规则, 切片的指针在闭包中判断
+	if info.elem.kind == tags.Slice {
+		expr, err := c.genValue(*info.elem, key, "*"+path, rest)
+		if err != nil {
+			return err
+		}
+		*values = append(*values, fmt.Sprintf("validator.Nested(\"\", %s)", nilGuard(path, []string{expr})))
+		return nil
+	}
+	expr, err := c.genValue(info, key, path, rest)
+	if err != nil {
+		return err
+	}
+	*values = append(*values, expr)
+	return nil
+}
+
+// genNested 结构体生成 validator.Nested, 结构体切片生成 validator.Each, 其他类型返回 false
+func (c *generator) genNested(expr ast.Expr, key, path string, rules []tags.Rule, values *[]string) (bool, error) {
+	if st, name := c.structOf(expr); st != nil {
+		if len(rules) > 0 {
+			return true, errors.New("rules are not supported on struct")
+		}
+		var child []string
+		if err := c.genStructFields(st, name, path, &child); err != nil || len(child) == 0 {
+			return true, err
+		}
+		*values = append(*values, fmt.Sprintf("validator.Nested(%q, %s)", key, joinValues(child)))
+		return true, nil
+	}
+
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		st, name := c.structOf(t.X)
+		if st == nil {
+			return false, nil
+		}
+		for _, r := range rules {
+			if _, _, err := tags.Lookup(tags.Pointer, r); err != nil {
+				return true, errors.New("rules are not supported on struct")
+			}
+			*values = append(*values, fmt.Sprintf("validator.Pointer(%q, %s).Required()", key, path))
+		}
+		var child []string
+		if err := c.genStructFields(st, name, path, &child); err != nil || len(child) == 0 {
+			return true, err
+		}
+		*values = append(*values, fmt.Sprintf("validator.Nested(%q, %s)", key, nilGuard(path, child)))
+		return true, nil
+	case *ast.ArrayType:
+		var elem, pointer = t.Elt, false
+		if star, ok := elem.(*ast.StarExpr); ok {
+			elem, pointer = star.X, true
+		}
+		st, name := c.structOf(elem)
+		if st == nil {
+			return false, nil
+		}
+		if len(rules) > 0 {
+			expr, err := c.genValue(kindInfo{kind: tags.Slice, array: t.Len != nil}, key, path, rules)
+			if err != nil {
+				return true, err
+			}
+			*values = append(*values, expr)
+		}
+		var child []string
+		if err := c.genStructFields(st, name, "elem", &child); err != nil || len(child) == 0 {
+			return true, err
+		}
+		var body = "return []validator.Valuer{" + joinValues(child) + "}\n"
+		if pointer {
+			body = "if elem == nil {\nreturn nil\n}\n" + body
+		}
+		if t.Len != nil {
+			path += "[:]"
+		}
+		*values = append(*values, fmt.Sprintf("validator.Each(%q, %s, func(_ int, elem %s) []validator.Valuer {\n%s})", key, path, types.ExprString(t.Elt), body))
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// genStructFields 生成结构体字段的校验器, 递归的类型无法生成代码
+func (c *generator) genStructFields(st *ast.StructType, name, recv string, values *[]string) error {
+	if c.visiting[name] {
+		return fmt.Errorf("recursive type %s is not supported", name)
+	}
+	c.visiting[name] = true
+	defer delete(c.visiting, name)
+	return c.genFields(st, recv, values)
+}
+
+// joinValues 生成多行的校验器参数列表
+func joinValues(values []string) string {
+	var sb strings.Builder
+	sb.WriteString("\n")
+	for _, item := range values {
+		fmt.Fprintf(&sb, "%s,\n", item)
+	}
+	return sb.String()
+}
+
+// nilGuard 指针非空时才返回校验器, 保持字段的声明顺序
+func nilGuard(path string, values []string) string {
+	return fmt.Sprintf("func() []validator.Valuer {\nif %s == nil {\nreturn nil\n}\nreturn []validator.Valuer{%s}\n}()...", path, joinValues(values))
+}
+
+// structOf 解析包内的结构体类型及其名字
+func (c *generator) structOf(expr ast.Expr) (*ast.StructType, string) {
+	for depth := 0; depth < 16; depth++ {
+		ident, ok := expr.(*ast.Ident)
+		if !ok {
+			return nil, ""
+		}
+		switch t := c.types[ident.Name].(type) {
+		case *ast.StructType:
+			return t, ident.Name
+		case *ast.Ident:
+			expr = t
+		default:
+			return nil, ""
+		}
+	}
+	return nil, ""
+}
+
+// genValue 生成构造器及规则的链式调用
+func (c *generator) genValue(info kindInfo, key string, expr string, rules []tags.Rule) (string, error) {
+	var sb strings.Builder
+	switch info.kind {
+	case tags.String:
+		fmt.Fprintf(&sb, "validator.String(%q, %s)", key, expr)
+	case tags.Number:
+		fmt.Fprintf(&sb, "validator.Ordered(%q, %s)", key, expr)
+	case tags.Pointer:
+		// 字符串及数值的指针, 规则作用于指向的值
+		if info.elem.kind == tags.String {
+			fmt.Fprintf(&sb, "validator.PointerString(%q, %s)", key, expr)
+		} else {
+			fmt.Fprintf(&sb, "validator.PointerOrdered(%q, %s)", key, expr)
+		}
+		info = *info.elem
+	default:
+		if info.array && strings.HasPrefix(expr, "*") {
+			expr = "(" + expr + ")[:]"
+		} else if info.array {
+			expr += "[:]"
+		}
+		if info.elem == nil {
+			fmt.Fprintf(&sb, "validator.SliceOf(%q, %s)", key, expr)
+		} else {
+			fmt.Fprintf(&sb, "validator.Slice(%q, %s)", key, expr)
+		}
+	}
+	for _, r := range rules {
+		spec, params, err := tags.Lookup(info.kind, r)
+		if err != nil {
+			return "", err
+		}
+		if info.kind == tags.Slice && info.elem == nil && !sliceOfMethods[spec.Method] {
+			return "", fmt.Errorf("rule %s is not supported on slice of this element type", r.Name)
+		}
+		var args = make([]string, 0, len(params))
+		for _, item := range params {
+			arg, err := formatArg(info, spec, item)
+			if err != nil {
+				return "", fmt.Errorf("rule %s: %w", r.Name, err)
+			}
+			args = append(args, arg)
+		}
+		fmt.Fprintf(&sb, ".%s(%s)", spec.Method, strings.Join(args, ", "))
+	}
+	return sb.String(), nil
+}
+
+// sliceOfMethods validator.SliceOf 支持的规则
+var sliceOfMethods = map[string]bool{
+	"Required": true,
+	"Eq":       true,
+	"Gt":       true,
+	"Gte":      true,
+	"Lt":       true,
+	"Lte":      true,
+	"Unique":   true,
+}
+
+// formatArg 将规则参数格式化为Go字面量, 整数与 validator.Struct 一样为十进制, 数值需在字段类型的范围内且有限, 正则表达式需能编译
+func formatArg(info kindInfo, spec tags.Spec, arg string) (string, error) {
+	if spec.Arg == tags.Int {
+		return arg, nil
+	}
+	if spec.Arg == tags.Regexp {
+		if _, err := regexp.Compile(arg); err != nil {
+			return "", err
+		}
+		return strconv.Quote(arg), nil
+	}
+	if info.kind == tags.Slice {
+		info = *info.elem
+	}
+	switch {
+	case info.kind == tags.String:
+		return strconv.Quote(arg), nil
+	case info.integer && info.unsigned:
+		if _, err := strconv.ParseUint(arg, 10, info.bits); err != nil {
+			return "", fmt.Errorf("invalid uint%d %s", info.bits, arg)
+		}
+		return arg, nil
+	case info.integer:
+		if _, err := strconv.ParseInt(arg, 10, info.bits); err != nil {
+			return "", fmt.Errorf("invalid int%d %s", info.bits, arg)
+		}
+		return arg, nil
+	default:
+		// Inf 和 NaN 不是Go字面量
+		if f, err := strconv.ParseFloat(arg, info.bits); err != nil || math.IsInf(f, 0) || math.IsNaN(f) {
+			return "", fmt.Errorf("invalid float%d %s", info.bits, arg)
+		}
+		return arg, nil
+	}
+}
+
+// kindOf 解析字段类型
+func (c *generator) kindOf(expr ast.Expr, depth int) (kindInfo, error) {
+	if depth > 16 {
+		return kindInfo{}, errors.New("type definition is too deep")
+	}
+	switch t := expr.(type) {
+	case *ast.Ident:
+		switch t.Name {
+		case "string":
+			return kindInfo{kind: tags.String}, nil
+		}
+		if info, ok := numbers[t.Name]; ok {
+			return info, nil
+		}
+		if underlying, ok := c.types[t.Name]; ok {
+			return c.kindOf(underlying, depth+1)
+		}
+		return kindInfo{}, fmt.Errorf("unsupported type %s", t.Name)
+	case *ast.StarExpr:
+		elem, err := c.kindOf(t.X, depth+1)
+		if err != nil {
+			return kindInfo{}, err
+		}
+		return kindInfo{kind: tags.Pointer, elem: &elem}, nil
+	case *ast.ArrayType:
+		var info = kindInfo{kind: tags.Slice, array: t.Len != nil}
+		// 其他类型的元素使用 validator.SliceOf, elem 为空
+		if elem, err := c.kindOf(t.Elt, depth+1); err == nil && (elem.kind == tags.String || elem.kind == tags.Number) {
+			info.elem = &elem
+		}
+		return info, nil
+	default:
+		return kindInfo{}, fmt.Errorf("unsupported type %T", expr)
+	}
+}
+
+// hasTag 结构体或递归校验的字段是否包含 validate 标签
+func (c *generator) hasTag(st *ast.StructType, seen map[*ast.StructType]bool) bool {
+	if seen[st] {
+		return false
+	}
+	seen[st] = true
+	for _, field := range st.Fields.List {
+		if field.Tag != nil {
+			s, _ := strconv.Unquote(field.Tag.Value)
+			if v, ok := reflect.StructTag(s).Lookup("validate"); ok && v != "" {
+				if v == "-" {
+					continue
+				}
+				return true
+			}
+		}
+		if len(field.Names) > 0 && !field.Names[0].IsExported() {
+			continue
+		}
+		var expr = field.Type
+		if t, ok := expr.(*ast.StarExpr); ok {
+			expr = t.X
+		} else if t, ok := expr.(*ast.ArrayType); ok {
+			expr = t.Elt
+			if t, ok := expr.(*ast.StarExpr); ok {
+				expr = t.X
+			}
+		}
+		if child, _ := c.structOf(expr); child != nil && c.hasTag(child, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+func contains(arr []string, target string) bool {
+	for i := range arr {
+		if arr[i] == target {
+			return true
+		}
+	}
+	return false
+}
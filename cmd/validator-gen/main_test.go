@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/xray-family/validator"
+	"github.com/xray-family/validator/cmd/validator-gen/testdata"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Run("", func(t *testing.T) {
+		buf, err := generate("testdata/req.go", nil)
+		assert.NoError(t, err)
+		expected, _ := os.ReadFile("testdata/req_validator.go")
+		assert.Equal(t, string(expected), string(buf))
+	})
+
+	t.Run("", func(t *testing.T) {
+		buf, err := generate("testdata/req.go", []string{"Page", "NoTag"})
+		assert.NoError(t, err)
+		assert.Contains(t, string(buf), "func (c *Page) Validate(r *http.Request) error")
+		assert.Contains(t, string(buf), "func (c *NoTag) Validate(r *http.Request) error")
+		assert.NotContains(t, string(buf), "func (c *Req)")
+	})
+}
+
+func TestGenerate_Nested(t *testing.T) {
+	var newOrder = func() testdata.Order {
+		return testdata.Order{
+			Address: testdata.Address{City: "a"},
+			Items:   []testdata.Item{{Name: "a"}},
+			Pair:    [2]testdata.Item{{Name: "a"}, {Name: "b"}},
+			Owner:   &testdata.Page{},
+		}
+	}
+	var cases = []func(o *testdata.Order){
+		func(o *testdata.Order) {},
+		func(o *testdata.Order) { o.Address.City = "" },
+		func(o *testdata.Order) { o.Address.Zip = new(string) },
+		func(o *testdata.Order) { o.Billing = &testdata.Address{} },
+		func(o *testdata.Order) { o.Items = nil },
+		func(o *testdata.Order) { o.Items = append(o.Items, testdata.Item{}) },
+		func(o *testdata.Order) { o.Refs = []*testdata.Item{nil, {}} },
+		func(o *testdata.Order) { o.Pair[1].Name = "" },
+		func(o *testdata.Order) { o.Owner = nil },
+		func(o *testdata.Order) { o.Owner.Size = 101 },
+		func(o *testdata.Order) { o.Wrapper.Page.Size = 101 },
+		func(o *testdata.Order) { o.Skip.Name = ""; o.Nodes = []testdata.NoTag{{}} },
+		func(o *testdata.Order) { o.Billing = &testdata.Address{City: "a", Zip: new(string)}; o.Items = nil },
+		func(o *testdata.Order) { o.Address.Zip = new(string); o.Owner = nil },
+	}
+	var r = httptest.NewRequest("GET", "/", nil)
+	for i, f := range cases {
+		var order = newOrder()
+		f(&order)
+		var expected = fmt.Sprint(validator.NewValidator(r).Validate(validator.Struct(&order)))
+		assert.Equal(t, expected, fmt.Sprint(order.Validate(r)), i)
+	}
+}
+
+func TestGenerate_Embedded(t *testing.T) {
+	var tags, bio = []string{}, testdata.Status("c")
+	var cases = []testdata.Profile{
+		{Nickname: "aha"},
+		{},
+		{Nickname: "aha", Base: &testdata.Base{}},
+		{Base: &testdata.Base{}},
+		{Nickname: "ahaahaaha", Base: &testdata.Base{ID: 1}},
+		{Nickname: "aha", Tags: &tags},
+		{Nickname: "aha", Bio: &bio, Tags: &tags},
+	}
+	var r = httptest.NewRequest("GET", "/", nil)
+	for i, item := range cases {
+		var expected = fmt.Sprint(validator.NewValidator(r).Validate(validator.Struct(&item)))
+		assert.Equal(t, expected, fmt.Sprint(item.Validate(r)), i)
+	}
+	var expected = validator.NewValidator(r).ValidateAll(validator.Struct(&testdata.Profile{Base: &testdata.Base{}}))
+	assert.Equal(t, "Nickname cannot be empty; Base.id cannot be empty", expected.Error())
+}
+
+func TestGenerate_Error(t *testing.T) {
+	var cases = []string{
+		"type Req struct { Age int `validate:\"email\"` }",
+		"type Req struct { Name string `validate:\"emial\"` }",
+		"type Req struct { Name string `validate:\"gte=a\"` }",
+		"type Req struct { Name string `validate:\"required,,\"` }",
+		"type Req struct { Age int `validate:\"gte=1.5\"` }",
+		"type Req struct { Age float64 `validate:\"gte=a\"` }",
+		"type Req struct { Age uint8 `validate:\"gte=-1\"` }",
+		"type Req struct { Age uint8 `validate:\"lte=300\"` }",
+		"type Req struct { Age int8 `validate:\"between=-129 0\"` }",
+		"type Req struct { Age rune `validate:\"gt=2147483648\"` }",
+		"type Req struct { Age uint `validate:\"in=1 -1\"` }",
+		"type Req struct { Age float32 `validate:\"lt=1e39\"` }",
+		"type Req struct { Roles []byte `validate:\"contains=256\"` }",
+		"type Req struct { Phone string `validate:\"matchstring=^[0-9\"` }",
+		"type Req struct { Score float64 `validate:\"gte=Inf\"` }",
+		"type Req struct { Score float64 `validate:\"lte=-infinity\"` }",
+		"type Req struct { Score float32 `validate:\"in=1 NaN\"` }",
+		"type Req struct { Age int `validate:\"gte=0x10\"` }",
+		"type Req struct { Age uint16 `validate:\"lte=0o17\"` }",
+		"type Req struct { Age int `validate:\"lte=1_000\"` }",
+		"type Req struct { http.Header; Name string `validate:\"required\"` }",
+		"type Req struct { Roles []int `validate:\"contains=a\"` }",
+		"type Req struct { Flags []bool `validate:\"contains=true\"` }",
+		"type Req struct { Flags []bool `validate:\"sorted\"` }",
+		"type Req struct { At time.Time `validate:\"required\"` }",
+		"type Req struct { P **int `validate:\"gt=1\"` }",
+		"type Req struct { P *bool `validate:\"required,gt=1\"` }",
+		"type Req struct { M map[string]int `validate:\"required\"` }",
+		"type Loop Loop; type Req struct { L Loop `validate:\"required\"` }",
+		"type Req struct { Name string }",
+		"type Item struct { Name string }; type Req struct { Items []Item }",
+		"type Node struct { Name string `validate:\"required\"`; Children []Node }",
+		"type Node struct { Name string `validate:\"required\"`; Parent *Node }",
+		"type Item struct { Name string `validate:\"required\"` }; type Req struct { Item Item `validate:\"required\"` }",
+		"type Item struct { Name string `validate:\"required\"` }; type Req struct { Item *Item `validate:\"gt=1\"` }",
+		"type Req struct { Name string `validate:\"required\"`",
+	}
+	for _, item := range cases {
+		var dir = t.TempDir()
+		var filename = filepath.Join(dir, "req.go")
+		_ = os.WriteFile(filename, []byte("package req\n\n"+item+"\n"), 0644)
+		_, err := generate(filename, nil)
+		assert.Error(t, err, item)
+	}
+
+	t.Run("", func(t *testing.T) {
+		_, err := generate(filepath.Join(t.TempDir(), "req.go"), nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("", func(t *testing.T) {
+		var dir = t.TempDir()
+		var filename = filepath.Join(dir, "req.go")
+		_ = os.WriteFile(filename, []byte("package req\n\ntype Req struct { Name string `validate:\"required\"` }\n"), 0644)
+		_ = os.WriteFile(filepath.Join(dir, "broken.go"), []byte("package req\n\ntype"), 0644)
+		_, err := generate(filename, nil)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,72 @@
+package testdata
+
+//go:generate go run github.com/xray-family/validator/cmd/validator-gen
+
+type Status string
+
+type Base struct {
+	ID int64 `json:"id" validate:"required,gt=0"`
+}
+
+type Req struct {
+	Base
+	Name   string    `json:"name" validate:"required,alphabet,lte=20"`
+	Age    int       `json:"age" validate:"between=18 60"`
+	Score  float64   `validate:"gte=0.5"`
+	Status Status    `json:"status" validate:"in=active disabled"`
+	Roles  []int     `json:"roles" validate:"required,contains=1,unique,subsetOf=1 2 3"`
+	Codes  [2]string `json:"codes" validate:"eq=2"`
+	Phone  string    `json:"phone" validate:"matchString=^1[0-9]+$"`
+	Zone   string    `json:"zone" validate:"matchString=^[a-z]{2\\,4}$"`
+	Email  *string   `json:"email,omitempty" validate:"required,email"`
+	Parent *int      `json:"parent" validate:"gt=0"`
+	Flags  []bool    `json:"flags" validate:"lte=3,unique"`
+	Level  uint8     `json:"level" validate:"between=0 255"`
+	Offset int8      `json:"offset" validate:"gte=-128"`
+	Note   string
+	secret string `validate:"required"`
+}
+
+type Address struct {
+	City string  `json:"city" validate:"required"`
+	Zip  *string `json:"zip" validate:"numeric"`
+}
+
+type Item struct {
+	Name string `json:"name" validate:"required"`
+	Note string
+}
+
+type Order struct {
+	Address Address   `json:"address"`
+	Billing *Address  `json:"billing"`
+	Items   []Item    `json:"items" validate:"required,lte=10"`
+	Refs    []*Item   `json:"refs"`
+	Pair    [2]Item   `json:"pair"`
+	Owner   *Page     `json:"owner" validate:"required"`
+	Skip    Item      `validate:"-"`
+	Empty   NoTag     `json:"empty"`
+	Nodes   []NoTag   `json:"nodes"`
+	Wrapper OrderInfo `json:"info"`
+}
+
+type OrderInfo struct {
+	Page Page `json:"page"`
+}
+
+type Nickname string
+
+type Profile struct {
+	Nickname `validate:"required,lte=8"`
+	*Base
+	Tags *[]string `json:"tags" validate:"gt=0"`
+	Bio  *Status   `json:"bio" validate:"in=a b"`
+}
+
+type Page struct {
+	Size int `json:"size" validate:"lte=100"`
+}
+
+type NoTag struct {
+	Name string
+}
@@ -0,0 +1,135 @@
+// Code generated by validator-gen. DO NOT EDIT.
+
+package testdata
+
+import (
+	"github.com/xray-family/validator"
+	"net/http"
+)
+
+func (c *Base) Validate(r *http.Request) error {
+	return validator.NewValidator(r).Validate(
+		validator.Ordered("id", c.ID).Required().Gt(0),
+	)
+}
+
+func (c *Req) Validate(r *http.Request) error {
+	return validator.NewValidator(r).Validate(
+		validator.Ordered("id", c.ID).Required().Gt(0),
+		validator.String("name", c.Name).Required().Alphabet().Lte(20),
+		validator.Ordered("age", c.Age).Between(18, 60),
+		validator.Ordered("Score", c.Score).Gte(0.5),
+		validator.String("status", c.Status).In("active", "disabled"),
+		validator.Slice("roles", c.Roles).Required().Contains(1).Unique().SubsetOf(1, 2, 3),
+		validator.Slice("codes", c.Codes[:]).Eq(2),
+		validator.String("phone", c.Phone).MatchString("^1[0-9]+$"),
+		validator.String("zone", c.Zone).MatchString("^[a-z]{2,4}$"),
+		validator.Pointer("email", c.Email).Required(),
+		validator.PointerString("email", c.Email).Email(),
+		validator.PointerOrdered("parent", c.Parent).Gt(0),
+		validator.SliceOf("flags", c.Flags).Lte(3).Unique(),
+		validator.Ordered("level", c.Level).Between(0, 255),
+		validator.Ordered("offset", c.Offset).Gte(-128),
+	)
+}
+
+func (c *Address) Validate(r *http.Request) error {
+	return validator.NewValidator(r).Validate(
+		validator.String("city", c.City).Required(),
+		validator.PointerString("zip", c.Zip).Numeric(),
+	)
+}
+
+func (c *Item) Validate(r *http.Request) error {
+	return validator.NewValidator(r).Validate(
+		validator.String("name", c.Name).Required(),
+	)
+}
+
+func (c *Order) Validate(r *http.Request) error {
+	return validator.NewValidator(r).Validate(
+		validator.Nested("address",
+			validator.String("city", c.Address.City).Required(),
+			validator.PointerString("zip", c.Address.Zip).Numeric(),
+		),
+		validator.Nested("billing", func() []validator.Valuer {
+			if c.Billing == nil {
+				return nil
+			}
+			return []validator.Valuer{
+				validator.String("city", c.Billing.City).Required(),
+				validator.PointerString("zip", c.Billing.Zip).Numeric(),
+			}
+		}()...),
+		validator.SliceOf("items", c.Items).Required().Lte(10),
+		validator.Each("items", c.Items, func(_ int, elem Item) []validator.Valuer {
+			return []validator.Valuer{
+				validator.String("name", elem.Name).Required(),
+			}
+		}),
+		validator.Each("refs", c.Refs, func(_ int, elem *Item) []validator.Valuer {
+			if elem == nil {
+				return nil
+			}
+			return []validator.Valuer{
+				validator.String("name", elem.Name).Required(),
+			}
+		}),
+		validator.Each("pair", c.Pair[:], func(_ int, elem Item) []validator.Valuer {
+			return []validator.Valuer{
+				validator.String("name", elem.Name).Required(),
+			}
+		}),
+		validator.Pointer("owner", c.Owner).Required(),
+		validator.Nested("owner", func() []validator.Valuer {
+			if c.Owner == nil {
+				return nil
+			}
+			return []validator.Valuer{
+				validator.Ordered("size", c.Owner.Size).Lte(100),
+			}
+		}()...),
+		validator.Nested("info",
+			validator.Nested("page",
+				validator.Ordered("size", c.Wrapper.Page.Size).Lte(100),
+			),
+		),
+	)
+}
+
+func (c *OrderInfo) Validate(r *http.Request) error {
+	return validator.NewValidator(r).Validate(
+		validator.Nested("page",
+			validator.Ordered("size", c.Page.Size).Lte(100),
+		),
+	)
+}
+
+func (c *Profile) Validate(r *http.Request) error {
+	return validator.NewValidator(r).Validate(
+		validator.String("Nickname", c.Nickname).Required().Lte(8),
+		validator.Nested("Base", func() []validator.Valuer {
+			if c.Base == nil {
+				return nil
+			}
+			return []validator.Valuer{
+				validator.Ordered("id", c.Base.ID).Required().Gt(0),
+			}
+		}()...),
+		validator.Nested("", func() []validator.Valuer {
+			if c.Tags == nil {
+				return nil
+			}
+			return []validator.Valuer{
+				validator.Slice("tags", *c.Tags).Gt(0),
+			}
+		}()...),
+		validator.PointerString("bio", c.Bio).In("a", "b"),
+	)
+}
+
+func (c *Page) Validate(r *http.Request) error {
+	return validator.NewValidator(r).Validate(
+		validator.Ordered("size", c.Size).Lte(100),
+	)
+}
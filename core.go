@@ -0,0 +1,148 @@
+package validator
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+)
+
+// core 各类校验器共用的状态和规则, 具体类型嵌入它并提供链式方法
+type core struct {
+	key      string
+	label    string
+	mark     bool
+	all      bool
+	skip     bool
+	omit     bool
+	optional bool // 调用过 Optional, 值改变时重新判断 omit
+	absent   bool // 指针为 nil, 跳过全部规则, 不受 Optional 和 Default 影响
+	invert   bool
+	ran      bool                 // 执行过规则, 组合规则据此忽略空的或全部被跳过的规则集
+	invalid  *i18n.LocalizeConfig // 无法执行的规则, 组合规则中不会被反转
+	conf     *config
+	locConf  *i18n.LocalizeConfig
+	locConfs []*i18n.LocalizeConfig
+	last     *i18n.LocalizeConfig
+}
+
+func newCore(k string) core {
+	return core{key: k, conf: defaultConf()}
+}
+
+func (c *core) setConf(conf *config) {
+	c.conf = conf
+}
+
+func (c *core) setPrefix(prefix string) {
+	c.key = joinKey(prefix, c.key)
+}
+
+// setOptional 值为空时跳过后续规则
+func (c *core) setOptional(empty bool) {
+	c.optional = true
+	c.omit = empty
+}
+
+// update 值被 Default 或转换改变后, 重新判断可选字段是否为空
+func (c *core) update(empty bool) {
+	if c.optional {
+		c.omit = empty
+	}
+}
+
+// skipped 规则被 When, Optional 或 nil 指针跳过
+func (c *core) skipped() bool {
+	return c.skip || c.omit || c.absent
+}
+
+// done 规则被跳过, 或已失败且不需要检查全部规则
+func (c *core) done() bool {
+	return c.skipped() || (c.mark && !c.all)
+}
+
+// check 记录规则的结果, 失败时保存消息
+func (c *core) check(messageId string, ok bool, args ...any) {
+	if c.invert {
+		ok = !ok
+	}
+	c.last = nil
+	if c.done() {
+		return
+	}
+	c.ran = true
+	if !ok {
+		c.add(newLocalizeConfig(messageId, args))
+	}
+}
+
+// checkField 检查与其他字段有关的规则, 其他字段的键作为 Arg0, 本地化后作为 {{.Other}}
+func (c *core) checkField(messageId string, ok bool, other string, args ...any) {
+	c.check(messageId, ok, append([]any{other}, args...)...)
+	if c.last != nil {
+		c.last.TemplateData.(map[string]any)[otherKey] = otherField(other)
+	}
+}
+
+// fail 记录无法执行的规则, 如错误的正则表达式, 在 Not 中也不会被反转, 组合规则原样报告它
+func (c *core) fail(locConf *i18n.LocalizeConfig) {
+	c.last = nil
+	if c.done() {
+		return
+	}
+	c.ran = true
+	c.add(locConf)
+	if c.invalid == nil {
+		c.invalid = locConf
+	}
+}
+
+// ignore 组合规则的规则集为空或全部被跳过, 不产生结果
+func (c *core) ignore() {
+	c.last = nil
+}
+
+// add 保存失败规则的消息
+func (c *core) add(locConf *i18n.LocalizeConfig) {
+	c.mark = true
+	if c.locConf == nil {
+		c.locConf = locConf
+	}
+	c.locConfs = append(c.locConfs, locConf)
+	c.last = locConf
+}
+
+// msgs 替换上一条失败规则的消息
+func (c *core) msgs(texts map[language.Tag]string) {
+	setMessages(c.last, texts)
+}
+
+// msgID 替换上一条失败规则的消息 id
+func (c *core) msgID(id string) {
+	if c.last != nil {
+		c.last.MessageID = id
+	}
+}
+
+// requiredIf 其他字段等于 want 时, 值不能为空, want 会转换为其他字段的类型
+func (c *core) requiredIf(present bool, other string, otherVal, want any) {
+	c.checkField("Conditional.RequiredIf", !equalValue(otherVal, want) || present, other, want)
+}
+
+// requiredWith 其他字段非空时, 值不能为空
+func (c *core) requiredWith(present bool, other string, otherVal any) {
+	c.checkField("Conditional.RequiredWith", !isPresent(otherVal) || present, other)
+}
+
+// requiredWithout 其他字段为空时, 值不能为空
+func (c *core) requiredWithout(present bool, other string, otherVal any) {
+	c.checkField("Conditional.RequiredWithout", isPresent(otherVal) || present, other)
+}
+
+// excludedWith 其他字段非空时, 值必须为空
+func (c *core) excludedWith(present bool, other string, otherVal any) {
+	c.checkField("Conditional.ExcludedWith", !isPresent(otherVal) || !present, other)
+}
+
+// fieldError 生成字段错误
+func (c *core) fieldError(val any) error {
+	return buildError(c.conf, c.key, c.label, val, c.locConfs)
+}
@@ -0,0 +1,148 @@
+package validator
+
+import (
+	"golang.org/x/text/language"
+	"time"
+)
+
+// DurationValue validates a time.Duration, the bounds in messages are formatted like 1h30m0s.
+type DurationValue struct {
+	err error
+	core
+	val time.Duration
+}
+
+func Duration(k string, v time.Duration) *DurationValue {
+	return &DurationValue{
+		core: newCore(k),
+		val:  v,
+	}
+}
+
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *DurationValue) All() *DurationValue {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *DurationValue) Label(id string) *DurationValue {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *DurationValue) Msg(text string) *DurationValue {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *DurationValue) Msgs(texts map[language.Tag]string) *DurationValue {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *DurationValue) MsgID(id string) *DurationValue {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *DurationValue) When(cond bool) *DurationValue {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *DurationValue) Unless(cond bool) *DurationValue {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *DurationValue) Optional() *DurationValue {
+	c.setOptional(c.val == 0)
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *DurationValue) RequiredIf(other string, otherVal, want any) *DurationValue {
+	c.requiredIf(c.val != 0, other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *DurationValue) RequiredWith(other string, otherVal any) *DurationValue {
+	c.requiredWith(c.val != 0, other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *DurationValue) RequiredWithout(other string, otherVal any) *DurationValue {
+	c.requiredWithout(c.val != 0, other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *DurationValue) ExcludedWith(other string, otherVal any) *DurationValue {
+	c.excludedWith(c.val != 0, other, otherVal)
+	return c
+}
+
+func (c *DurationValue) validate(messageId string, ok bool, args ...any) *DurationValue {
+	c.check(messageId, ok, args...)
+	return c
+}
+
+// Err get error
+func (c *DurationValue) Err() error {
+	if !c.mark {
+		return nil
+	}
+	if c.err != nil {
+		return c.err
+	}
+	c.err = c.fieldError(c.val)
+	return c.err
+}
+
+// Required the duration cannot be zero
+func (c *DurationValue) Required() *DurationValue {
+	return c.validate("DurationValue.Required", c.val != 0)
+}
+
+// Gt check the duration is greater than v
+func (c *DurationValue) Gt(v time.Duration) *DurationValue {
+	return c.validate("DurationValue.Gt", c.val > v, v.String())
+}
+
+// Gte check the duration is greater or equal than v
+func (c *DurationValue) Gte(v time.Duration) *DurationValue {
+	return c.validate("DurationValue.Gte", c.val >= v, v.String())
+}
+
+// Lt check the duration is less than v
+func (c *DurationValue) Lt(v time.Duration) *DurationValue {
+	return c.validate("DurationValue.Lt", c.val < v, v.String())
+}
+
+// Lte check the duration is less or equal than v
+func (c *DurationValue) Lte(v time.Duration) *DurationValue {
+	return c.validate("DurationValue.Lte", c.val <= v, v.String())
+}
+
+// Between check that the duration satisfies a <= x < b
+func (c *DurationValue) Between(a, b time.Duration) *DurationValue {
+	return c.validate("DurationValue.Between", c.val >= a && c.val < b, a.String(), b.String())
+}
+
+func (c *DurationValue) Customize(messageId string, f func(time.Duration) bool) *DurationValue {
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
+}
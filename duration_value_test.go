@@ -0,0 +1,42 @@
+package validator
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestDurationValue_Required(t *testing.T) {
+	assert.Nil(t, Duration("ttl", time.Second).Required().Err())
+	assert.Equal(t, "ttl cannot be empty", Duration("ttl", 0).Required().Err().Error())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(Duration("ttl", 0).Required())
+		assert.Equal(t, "ttl 不能为空", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var value = Duration("ttl", 0).Required()
+		value.locConf.MessageID = "aha"
+		assert.Error(t, value.Err())
+		assert.Error(t, value.Err())
+	})
+}
+
+func TestDurationValue_Range(t *testing.T) {
+	var d = 90 * time.Second
+	assert.Nil(t, Duration("ttl", d).Gt(time.Minute).Gte(d).Lt(2*time.Minute).Lte(d).Between(d, 2*time.Minute).Err())
+	assert.Equal(t, "ttl must be greater than 2m0s", Duration("ttl", d).Gt(2*time.Minute).Err().Error())
+	assert.Equal(t, "ttl must be greater than or equal to 1h30m0s", Duration("ttl", d).Gte(90*time.Minute).Err().Error())
+	assert.Equal(t, "ttl must be less than 1m0s", Duration("ttl", d).Lt(time.Minute).Err().Error())
+	assert.Equal(t, "ttl must be less than or equal to 500ms", Duration("ttl", d).Lte(500*time.Millisecond).Err().Error())
+	assert.Equal(t, "ttl must satisfy 1s<=x<1m0s", Duration("ttl", d).Between(time.Second, time.Minute).Err().Error())
+	assert.Equal(t, 2, len(Duration("ttl", d).All().Lt(time.Second).Gt(time.Hour).Err().(Errors)))
+	assert.Equal(t, "cache.ttl must be less than 1m0s", Nested("cache", Duration("ttl", d).Lt(time.Minute)).Err().Error())
+}
+
+func TestDurationValue_Customize(t *testing.T) {
+	var isRound = func(d time.Duration) bool { return d%time.Second == 0 }
+	assert.Nil(t, Duration("ttl", time.Second).Customize("DurationValue.Customize", isRound).Err())
+	assert.Equal(t, "ttl validation failed", Duration("ttl", time.Millisecond).Customize("DurationValue.Customize", isRound).Err().Error())
+}
@@ -0,0 +1,151 @@
+package validator
+
+import (
+	"fmt"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"net/http"
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	// _engine 包级函数使用的默认引擎
+	_engine atomic.Pointer[Engine]
+
+	// _mu 串行化默认引擎的读取-修改-替换, 避免并发的 AddMessages 等互相覆盖
+	_mu sync.Mutex
+
+	// assets 内置的语言文件只解析一次, 各个引擎共享
+	assets = sync.OnceValues(func() ([]locales, error) {
+		return readLocales(assetFS, "asset/*.json")
+	})
+)
+
+// Engine owns a message bundle and the default localizer, builders and validators get their messages from it.
+// An Engine is safe to share between goroutines, WithMessages and WithLocales return a new engine instead of changing it.
+type Engine struct {
+	tag       language.Tag
+	langs     []string
+	sources   []locales
+	bundle    *i18n.Bundle
+	localizer *i18n.Localizer
+	conf      *config
+}
+
+// NewEngine creates an engine with the embedded messages, tag is the default language of the bundle,
+// langs are the languages of the default localizer.
+func NewEngine(tag language.Tag, langs ...string) *Engine {
+	return mustEngine(newEngine(tag, langs, nil))
+}
+
+// WithLang returns a copy of the engine with another default language and localizer,
+// the messages added with WithMessages and WithLocales are kept.
+func (e *Engine) WithLang(tag language.Tag, langs ...string) *Engine {
+	return mustEngine(newEngine(tag, langs, e.sources))
+}
+
+// mustEngine 内置的语言文件由测试保证可用, 其他消息在加入引擎时已经校验过
+func mustEngine(e *Engine, err error) *Engine {
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+// newEngine 先加载内置的语言文件, 再依次添加 sources
+func newEngine(tag language.Tag, langs []string, sources []locales) (*Engine, error) {
+	list, err := assets()
+	if err != nil {
+		return nil, err
+	}
+	var bundle = i18n.NewBundle(tag)
+	for format, f := range unmarshalFuncs {
+		bundle.RegisterUnmarshalFunc(format, f)
+	}
+	for _, src := range append(list[:len(list):len(list)], sources...) {
+		if err := bundle.AddMessages(src.tag, src.msgs...); err != nil {
+			return nil, fmt.Errorf("validator: add messages %s: %w", src.tag, err)
+		}
+	}
+	var localizer = i18n.NewLocalizer(bundle, langs...)
+	return &Engine{
+		tag:       tag,
+		langs:     langs,
+		sources:   sources,
+		bundle:    bundle,
+		localizer: localizer,
+		conf:      &config{loc: localizer, tags: parseTags(langs...)},
+	}, nil
+}
+
+// Default returns the engine used by the package-level functions and builders.
+func Default() *Engine {
+	return _engine.Load()
+}
+
+// SetDefault replaces the engine used by the package-level functions and builders.
+// Builders created before the call keep the previous engine unless they are passed to a Validator.
+func SetDefault(e *Engine) {
+	_mu.Lock()
+	defer _mu.Unlock()
+	_engine.Store(e)
+}
+
+// updateDefault 在锁内基于当前的默认引擎创建新引擎并替换, 出错时保持不变
+func updateDefault(f func(e *Engine) (*Engine, error)) error {
+	_mu.Lock()
+	defer _mu.Unlock()
+	e, err := f(_engine.Load())
+	if err != nil {
+		return err
+	}
+	_engine.Store(e)
+	return nil
+}
+
+// defaultConf 默认引擎的配置, 构建校验器时使用
+func defaultConf() *config {
+	return Default().conf
+}
+
+// Bundle returns the message bundle of the engine.
+// Messages added to it directly are not safe to add while the engine is in use and are lost by WithMessages and WithLocales,
+// use WithMessages or AddMessages instead.
+func (e *Engine) Bundle() *i18n.Bundle {
+	return e.bundle
+}
+
+// WithMessages returns a copy of the engine with msgs added for tag, the messages are kept by the engines derived from it.
+func (e *Engine) WithMessages(tag language.Tag, msgs ...*i18n.Message) (*Engine, error) {
+	var sources = append(e.sources[:len(e.sources):len(e.sources)], locales{tag: tag, msgs: msgs})
+	return newEngine(e.tag, e.langs, sources)
+}
+
+// Localizer returns the default localizer of the engine
+func (e *Engine) Localizer() *i18n.Localizer {
+	return e.localizer
+}
+
+// NewValidator creates a validator that localizes messages with the lang query parameter
+// and the Accept-Language header of r, falling back to the default localizer of the engine.
+func (e *Engine) NewValidator(r *http.Request, options ...Option) *Validator {
+	options = append(options, withLang(e.bundle, r), withInit(e.conf.loc, e.conf.tags))
+	var conf = new(config)
+	for _, f := range options {
+		f(conf)
+	}
+	return &Validator{conf: conf}
+}
+
+// Validate checks the values in order with the default localizer of the engine, and returns the first error.
+func (e *Engine) Validate(values ...Valuer) error {
+	return validateValues(e.conf, values, false)
+}
+
+// ValidateAll runs every Valuer with the default localizer of the engine and returns an Errors holding all failures in order.
+func (e *Engine) ValidateAll(values ...Valuer) error {
+	var conf = *e.conf
+	conf.all = true
+	return validateValues(&conf, values, true)
+}
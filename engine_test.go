@@ -0,0 +1,110 @@
+package validator
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestEngine(t *testing.T) {
+	var engine = NewEngine(Chinese, Chinese.String())
+	assert.NotNil(t, engine.Bundle())
+	assert.NotNil(t, engine.Localizer())
+	assert.NotEqual(t, GetBundle(), engine.Bundle())
+
+	t.Run("", func(t *testing.T) {
+		assert.Equal(t, "name 不能为空", engine.Validate(String("name", "").Required()).Error())
+		assert.Equal(t, "name cannot be empty", Validate(String("name", "").Required()).Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = engine.ValidateAll(Nested("user", String("name", "").Required(), Ordered("age", 1).Gte(18)))
+		assert.Equal(t, 2, len(err.(Errors)))
+		assert.Equal(t, "user.name 不能为空", err.(Errors)[0].Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		assert.Equal(t, "name cannot be empty", engine.NewValidator(newReq("en-US")).Validate(String("name", "").Required()).Error())
+		assert.Equal(t, "name 不能为空", engine.NewValidator(nil).Validate(String("name", "").Required()).Error())
+	})
+}
+
+func TestEngine_WithMessages(t *testing.T) {
+	var engine = NewEngine(English, Chinese.String())
+	e, err := engine.WithMessages(Chinese, &i18n.Message{ID: "Phone", Other: "{{.Key}} 格式错误"})
+	assert.NoError(t, err)
+	assert.Equal(t, "phone 格式错误", e.Validate(String("phone", "1").Customize("Phone", func(string) bool { return false })).Error())
+	assert.Error(t, engine.Validate(String("phone", "1").Customize("Phone", func(string) bool { return false })))
+
+	t.Run("", func(t *testing.T) {
+		_, err := engine.WithMessages(language.Make("tlh"), &i18n.Message{ID: "Phone", Other: "x"})
+		assert.Error(t, err)
+	})
+
+	t.Run("", func(t *testing.T) {
+		var previous = Default()
+		defer SetDefault(previous)
+
+		assert.NoError(t, AddMessages(English, &i18n.Message{ID: "Field.phone", Other: "Phone"}))
+		assert.Equal(t, "Phone cannot be empty", String("phone", "").Required().Err().Error())
+		assert.Error(t, AddMessages(language.Make("tlh"), &i18n.Message{ID: "Field.phone", Other: "x"}))
+		assert.Equal(t, "Phone cannot be empty", String("phone", "").Required().Err().Error())
+	})
+
+	t.Run("concurrent", func(t *testing.T) {
+		var previous = Default()
+		defer SetDefault(previous)
+
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				var id = "Field.f" + strconv.Itoa(i)
+				assert.NoError(t, AddMessages(English, &i18n.Message{ID: id, Other: "F" + strconv.Itoa(i)}))
+			}(i)
+		}
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			SetLang(English, English.String())
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, LoadLocales(assetFS, "asset/active.en-US.json"))
+		}()
+		wg.Wait()
+		for i := 0; i < 20; i++ {
+			var key = "f" + strconv.Itoa(i)
+			assert.Equal(t, "F"+strconv.Itoa(i)+" cannot be empty", String(key, "").Required().Err().Error())
+		}
+	})
+}
+
+func TestSetDefault(t *testing.T) {
+	var previous = Default()
+	defer SetDefault(previous)
+
+	SetDefault(NewEngine(Chinese, Chinese.String()))
+	assert.Equal(t, "name 不能为空", String("name", "").Required().Err().Error())
+	assert.Equal(t, "name cannot be empty", NewValidator(newReq("en-US")).Validate(String("name", "").Required()).Error())
+
+	t.Run("race", func(t *testing.T) {
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				SetLang(English, English.String())
+			}()
+			go func() {
+				defer wg.Done()
+				assert.Error(t, NewValidator(newReq("zh-CN")).Validate(String("name", "").Required()))
+			}()
+		}
+		wg.Wait()
+	})
+}
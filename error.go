@@ -0,0 +1,236 @@
+package validator
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Errors is a collection of validation errors, kept in the order they were found.
+// It supports errors.Is and errors.As through Unwrap() []error.
+type Errors []error
+
+func (c Errors) Error() string {
+	var list = make([]string, 0, len(c))
+	for _, err := range c {
+		list = append(list, err.Error())
+	}
+	return strings.Join(list, "; ")
+}
+
+func (c Errors) Unwrap() []error {
+	return c
+}
+
+// FieldError describes a failed rule on a field.
+// Use errors.As to get it back from the error returned by Err, Validate or ValidateAll.
+type FieldError struct {
+	Key       string // field key
+	Label     string // localized field name used as {{.Key}} in the message, Key when there is no translation
+	MessageID string // i18n message id of the failed rule
+	Args      []any  // rule arguments, available as {{.Arg0}}, {{.Arg1}}... in the template
+	Message   string // localized message
+	Value     any    // rejected value
+}
+
+func (c *FieldError) Error() string {
+	return c.Message
+}
+
+// subRules 组合规则中的子规则, 生成错误时本地化并以"; "连接
+type subRules []*i18n.LocalizeConfig
+
+// localize 填充字段名并本地化, 子规则先于父规则本地化
+func localize(conf *config, name string, locConf *i18n.LocalizeConfig) (string, error) {
+	if td, ok := locConf.TemplateData.(map[string]any); ok {
+		td["Key"] = name
+		if other, ok := td[otherKey].(otherField); ok {
+			td[otherKey] = fieldName(conf, string(other), "")
+		}
+		for k, v := range td {
+			rules, ok := v.(subRules)
+			if !ok {
+				continue
+			}
+			var list = make([]string, 0, len(rules))
+			for _, item := range rules {
+				str, err := localize(conf, name, item)
+				if err != nil {
+					return "", err
+				}
+				list = append(list, str)
+			}
+			td[k] = strings.Join(list, "; ")
+		}
+		if texts, ok := td[msgKey].(messages); ok {
+			return texts.execute(conf, locConf)
+		}
+	}
+	return conf.loc.Localize(locConf)
+}
+
+// otherKey 模板数据中其他字段名的键, 与 {{.Key}} 一样本地化
+const otherKey = "Other"
+
+// otherField 未本地化的其他字段的键
+type otherField string
+
+// msgKey 模板数据中覆盖文本的键, 不是合法的字段名, 模板中无法引用
+const msgKey = "_msg"
+
+// messages 按语言覆盖规则的错误信息, language.Und 用于所有语言
+type messages map[language.Tag]string
+
+// setMessages 覆盖上一条规则的错误信息, 规则通过时 locConf 为 nil
+func setMessages(locConf *i18n.LocalizeConfig, texts map[language.Tag]string) {
+	if locConf != nil {
+		locConf.TemplateData.(map[string]any)[msgKey] = messages(texts)
+	}
+}
+
+// execute 按用户请求的语言选择文本并渲染, 没有可用的文本时使用规则原本的信息
+func (c messages) execute(conf *config, locConf *i18n.LocalizeConfig) (string, error) {
+	var text = c[language.Und]
+	var tags = make([]language.Tag, 0, len(c))
+	for k := range c {
+		if k != language.Und {
+			tags = append(tags, k)
+		}
+	}
+	if len(tags) > 0 && len(conf.tags) > 0 {
+		sort.Slice(tags, func(i, j int) bool { return tags[i].String() < tags[j].String() })
+		if _, i, confidence := language.NewMatcher(tags).Match(conf.tags...); confidence != language.No {
+			text = c[tags[i]]
+		}
+	}
+	if text == "" {
+		return conf.loc.Localize(locConf)
+	}
+	tpl, err := template.New(locConf.MessageID).Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	if err := tpl.Execute(&sb, locConf.TemplateData); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// fieldName 本地化字段名, 依次查找 label, Field.<key>, 以及嵌套键中每一段的 Field.<name>
+func fieldName(conf *config, key, label string) string {
+	if label != "" {
+		if str := localizeName(conf, label); str != "" {
+			return str
+		}
+	}
+	if str := localizeName(conf, "Field."+key); str != "" {
+		return str
+	}
+	if !strings.ContainsAny(key, ".[") {
+		return key
+	}
+	var parts = strings.Split(key, ".")
+	for i, part := range parts {
+		var name = part
+		if n := strings.IndexByte(part, '['); n >= 0 {
+			name = part[:n]
+		}
+		if name == "" {
+			continue
+		}
+		if str := localizeName(conf, "Field."+name); str != "" {
+			parts[i] = str + part[len(name):]
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+// localizeName 本地化字段名, 未注册时返回空字符串
+func localizeName(conf *config, messageId string) string {
+	str, _ := conf.loc.Localize(&i18n.LocalizeConfig{MessageID: messageId})
+	return str
+}
+
+// newFieldError 本地化错误信息并构建 FieldError
+func newFieldError(conf *config, key, name string, val any, locConf *i18n.LocalizeConfig) error {
+	str, err := localize(conf, name, locConf)
+	if err != nil {
+		return err
+	}
+	return &FieldError{
+		Key:       key,
+		Label:     name,
+		MessageID: locConf.MessageID,
+		Args:      templateArgs(locConf.TemplateData),
+		Message:   str,
+		Value:     val,
+	}
+}
+
+// buildError 将失败的规则转换为错误, 多条规则失败时返回 Errors
+func buildError(conf *config, key, label string, val any, locConfs []*i18n.LocalizeConfig) error {
+	var name = fieldName(conf, key, label)
+	if len(locConfs) == 1 {
+		return newFieldError(conf, key, name, val, locConfs[0])
+	}
+	var list = make(Errors, 0, len(locConfs))
+	for _, item := range locConfs {
+		err := newFieldError(conf, key, name, val, item)
+		if _, ok := err.(*FieldError); !ok {
+			return err
+		}
+		list = append(list, err)
+	}
+	return list
+}
+
+// composeError 合并自身规则与子校验器的错误, all为false时只返回第一个错误
+func composeError(conf *config, key, label string, val any, locConfs []*i18n.LocalizeConfig, values []Valuer, all bool) error {
+	var list Errors
+	if len(locConfs) > 0 {
+		err := buildError(conf, key, label, val, locConfs)
+		if !all {
+			return err
+		}
+		list = appendErrors(list, err)
+	}
+	if err := validateValues(conf, values, all); err != nil {
+		list = appendErrors(list, err)
+	}
+	switch len(list) {
+	case 0:
+		return nil
+	case 1:
+		return list[0]
+	default:
+		return list
+	}
+}
+
+// templateArgs 按顺序取出模板参数 Arg0, Arg1...
+func templateArgs(data any) []any {
+	td, ok := data.(map[string]any)
+	if !ok {
+		return nil
+	}
+	var args []any
+	for i := 0; ; i++ {
+		v, exist := td["Arg"+strconv.Itoa(i)]
+		if !exist {
+			return args
+		}
+		args = append(args, v)
+	}
+}
+
+// appendErrors 追加错误, 嵌套的 Errors 会被展开
+func appendErrors(list Errors, err error) Errors {
+	if v, ok := err.(Errors); ok {
+		return append(list, v...)
+	}
+	return append(list, err)
+}
@@ -0,0 +1,191 @@
+package validator
+
+import (
+	"errors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFieldError(t *testing.T) {
+	t.Run("", func(t *testing.T) {
+		var err = Ordered("Age", 15).Gte(18).Err()
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, "Age", fe.Key)
+		assert.Equal(t, "OrderedValue.Gte", fe.MessageID)
+		assert.Equal(t, []any{18}, fe.Args)
+		assert.Equal(t, 15, fe.Value)
+		assert.Equal(t, "Age must be greater than or equal to 18", fe.Message)
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).ValidateAll(
+			String("Name", " ").Required(),
+			Slice("Roles", []int{1}).Contains(2),
+		)
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, "Name", fe.Key)
+		assert.Equal(t, "StringValue.Required", fe.MessageID)
+		assert.Nil(t, fe.Args)
+		assert.Equal(t, "", fe.Value)
+		assert.Equal(t, "Name 不能为空", fe.Error())
+
+		var list = err.(Errors)
+		assert.True(t, errors.As(list[1], &fe))
+		assert.Equal(t, "Roles", fe.Key)
+		assert.Equal(t, []int{1}, fe.Value)
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = String("Name", "a").Gte(3).Err()
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, "Name length must be greater than or equal to 3", fe.Message)
+	})
+
+	t.Run("", func(t *testing.T) {
+		assert.Nil(t, templateArgs(nil))
+	})
+}
+
+func TestFieldError_Label(t *testing.T) {
+	e, _ := NewEngine(English).WithMessages(Chinese,
+		&i18n.Message{ID: "Field.Name", Other: "姓名"},
+		&i18n.Message{ID: "Field.address", Other: "地址"},
+		&i18n.Message{ID: "Field.Items", Other: "商品"},
+		&i18n.Message{ID: "Field.Price", Other: "价格"},
+		&i18n.Message{ID: "Label.Nickname", Other: "昵称"},
+		&i18n.Message{ID: "Field.Password", Other: "密码"},
+		&i18n.Message{ID: "Field.Type", Other: "类型"},
+	)
+	var v = e.NewValidator(newReq("zh-CN"))
+
+	t.Run("", func(t *testing.T) {
+		var err = v.Validate(String("Name", "").Required())
+		assert.Equal(t, "姓名 不能为空", err.Error())
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, "Name", fe.Key)
+		assert.Equal(t, "姓名", fe.Label)
+	})
+
+	t.Run("", func(t *testing.T) {
+		assert.Equal(t, "昵称 不能为空", v.Validate(String("nick", "").Label("Label.Nickname").Required()).Error())
+		assert.Equal(t, "nick 不能为空", v.Validate(String("nick", "").Label("Label.Missing").Required()).Error())
+		assert.Equal(t, "Age 须大于18", v.Validate(Ordered("Age", 1).Gt(18)).Error())
+		assert.Equal(t, "Name cannot be empty", e.NewValidator(newReq("en-US")).Validate(String("Name", "").Required()).Error())
+	})
+
+	t.Run("nested", func(t *testing.T) {
+		var err = v.ValidateAll(
+			Nested("address", String("city", "").Required()),
+			Each("Items", []int{0}, func(i int, price int) []Valuer {
+				return []Valuer{Ordered("Price", price).Required()}
+			}),
+		)
+		var list = err.(Errors)
+		assert.Equal(t, "地址.city 不能为空", list[0].Error())
+		assert.Equal(t, "商品[0].价格 不能为空", list[1].Error())
+		assert.Equal(t, "Items[0].Price", list[1].(*FieldError).Key)
+	})
+	t.Run("other field", func(t *testing.T) {
+		var err = v.Validate(String("Name", "a").EqField("Password", "b"))
+		assert.Equal(t, "姓名 须与密码一致", err.Error())
+		assert.Equal(t, []any{"Password"}, err.(*FieldError).Args)
+		assert.Equal(t, "类型 为 company 时 姓名 不能为空", v.Validate(String("Name", "").RequiredIf("Type", "company", "company")).Error())
+		assert.Equal(t, "Name must equal Password", e.NewValidator(newReq("en-US")).Validate(String("Name", "a").EqField("Password", "b")).Error())
+		assert.Equal(t, "x 密码", v.Validate(String("Name", "a").EqField("Password", "b").Msg("x {{.Other}}")).Error())
+	})
+}
+
+func TestMsg(t *testing.T) {
+	var v = NewValidator(newReq("zh-CN"))
+
+	t.Run("", func(t *testing.T) {
+		var err = String("name", "").Required().Msg("please enter {{.Key}}").Err()
+		assert.Equal(t, "please enter name", err.Error())
+		assert.Equal(t, "StringValue.Required", err.(*FieldError).MessageID)
+		assert.Equal(t, "age must be 18+, got 3", Ordered("age", 3).Gte(18).Msg("{{.Key}} must be {{.Arg0}}+, got 3").Err().Error())
+	})
+
+	t.Run("previous rule", func(t *testing.T) {
+		assert.Equal(t, "name cannot be empty", String("name", "").Required().Gte(0).Msg("aha").Err().Error())
+		assert.Equal(t, "name cannot be empty", String("name", "").Required().Lte(0).Msg("aha").Err().Error())
+		assert.Equal(t, "name cannot be empty", String("name", "").Required().MatchString(`^\d+$`).Msg("aha").Err().Error())
+		assert.Equal(t, "ids cannot be empty", Slice[int]("ids", nil).Required().Unique().Msg("aha").Err().Error())
+		assert.Equal(t, "age cannot be empty", Ordered("age", 0).Required().When(false).Gt(1).Msg("aha").Err().Error())
+		assert.Nil(t, String("name", "aha").Required().Msg("aha").Err())
+
+		var err = String("name", "").All().Required().Msg("a").Gte(2).Msg("b").Err()
+		assert.Equal(t, "a; b", err.Error())
+	})
+
+	t.Run("msg id", func(t *testing.T) {
+		var err = String("code", "a").Numeric().MsgID("StringValue.Alphabet").Err()
+		assert.Equal(t, "code must consist of letters only", err.Error())
+		assert.Equal(t, "StringValue.Alphabet", err.(*FieldError).MessageID)
+	})
+
+	t.Run("languages", func(t *testing.T) {
+		var texts = map[language.Tag]string{
+			language.Und:       "{{.Key}} is invalid",
+			Chinese:            "{{.Key}} 无效",
+			TraditionalChinese: "{{.Key}} 無效",
+		}
+		var newValue = func() Valuer { return Ordered("age", 3).Gte(18).Msgs(texts) }
+		assert.Equal(t, "age 无效", v.Validate(newValue()).Error())
+		assert.Equal(t, "age 無效", NewValidator(newReq("zh-TW")).Validate(newValue()).Error())
+		assert.Equal(t, "age is invalid", NewValidator(newReq("ja-JP")).Validate(newValue()).Error())
+		assert.Equal(t, "age is invalid", newValue().Err().Error())
+
+		var value = Ordered("age", 3).Gte(18).Msgs(map[language.Tag]string{Chinese: "{{.Key}} 无效"})
+		assert.Equal(t, "age must be greater than or equal to 18", value.Err().Error())
+	})
+
+	t.Run("requested languages", func(t *testing.T) {
+		var texts = map[language.Tag]string{
+			language.Und:             "{{.Key}} is invalid",
+			language.Chinese:         "{{.Key}} 无效",
+			language.MustParse("ru"): "{{.Key}} недействителен",
+		}
+		var newValue = func() Valuer { return Ordered("age", 3).Gte(18).Msgs(texts) }
+		assert.Equal(t, "age 无效", v.Validate(newValue()).Error())
+		assert.Equal(t, "age недействителен", NewValidator(newReq("ru-RU")).Validate(newValue()).Error())
+		assert.Equal(t, "age 无效", NewValidator(newReq("fr-FR;q=0.5, zh-CN;q=0.9")).Validate(newValue()).Error())
+
+		req, _ := http.NewRequest(http.MethodGet, "http://localhost?lang=zh", nil)
+		assert.Equal(t, "age 无效", NewValidator(req).Validate(newValue()).Error())
+		assert.Equal(t, "age is invalid", NewEngine(English, "ja-JP").Validate(newValue()).Error())
+	})
+
+	t.Run("unregistered message id", func(t *testing.T) {
+		var newValue = func() Valuer {
+			return String("name", "a").Customize("User.Name", func(string) bool { return false }).Msgs(map[language.Tag]string{
+				language.Und:     "{{.Key}} is taken",
+				language.Chinese: "{{.Key}} 已被使用",
+			})
+		}
+		var err = v.Validate(newValue())
+		var fieldErr *FieldError
+		assert.True(t, errors.As(err, &fieldErr))
+		assert.Equal(t, "name 已被使用", fieldErr.Message)
+		assert.Equal(t, "User.Name", fieldErr.MessageID)
+		assert.Equal(t, "name is taken", NewValidator(newReq("en-US")).Validate(newValue()).Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		assert.Error(t, String("name", "").Required().Msg("{{.Key").Err())
+		assert.Equal(t, "user.name 不能为空!", v.Validate(Nested("user", String("name", "").Required().Msg("{{.Key}} 不能为空!"))).Error())
+		assert.Equal(t, "at is required", Time("at", time.Time{}).Required().Msg("{{.Key}} is required").Err().Error())
+		assert.Equal(t, "x", Map[string, int]("m", nil).Required().Msg("x").Err().Error())
+		assert.Equal(t, "x", SliceOf[bool]("m", nil).Required().Msg("x").Err().Error())
+		assert.Equal(t, "x", Pointer[int]("m", nil).Required().Msg("x").Err().Error())
+		assert.Equal(t, "x", Any("m", 1).Customize("AnyValue.Customize", func(int) bool { return false }).Msg("x").Err().Error())
+		assert.Equal(t, "x", Duration("m", 0).Required().Msg("x").Err().Error())
+	})
+}
@@ -3,13 +3,15 @@ module github.com/xray-family/validator
 go 1.21
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/nicksnyder/go-i18n/v2 v2.5.1
+	github.com/rivo/uniseg v0.4.7
 	github.com/stretchr/testify v1.9.0
 	golang.org/x/text v0.21.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -2,44 +2,51 @@ package validator
 
 import (
 	"embed"
-	"encoding/json"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"golang.org/x/text/language"
 )
 
 var (
-	//go:embed asset/active.en-US.json
-	enFS embed.FS
-
-	//go:embed asset/active.zh-CN.json
-	cnFS embed.FS
-
-	_bundle    *i18n.Bundle
-	_localizer *i18n.Localizer
-	_conf      *config
-
-	Chinese = language.Make("zh-CN")
-	English = language.Make("en-US")
+	//go:embed asset/*.json
+	assetFS embed.FS
+
+	Chinese            = language.Make("zh-CN")
+	English            = language.Make("en-US")
+	Japanese           = language.Make("ja-JP")
+	Korean             = language.Make("ko-KR")
+	German             = language.Make("de-DE")
+	French             = language.Make("fr-FR")
+	Spanish            = language.Make("es-ES")
+	Portuguese         = language.Make("pt-BR")
+	TraditionalChinese = language.Make("zh-TW")
 )
 
 func init() {
-	SetLang(English, English.String())
+	SetDefault(NewEngine(English, English.String()))
 }
 
+// SetLang replaces the default engine with Default().WithLang(tag, langs...), it is safe to call at runtime.
+// The messages added with AddMessages and LoadLocales are kept.
 func SetLang(tag language.Tag, langs ...string) {
-	_bundle = i18n.NewBundle(tag)
-	_bundle.RegisterUnmarshalFunc("json", json.Unmarshal)
-	_, _ = _bundle.LoadMessageFileFS(enFS, `asset/active.en-US.json`)
-	_, _ = _bundle.LoadMessageFileFS(cnFS, `asset/active.zh-CN.json`)
-	_localizer = i18n.NewLocalizer(_bundle, langs...)
-	_conf = new(config)
-	withInit()(_conf)
+	_ = updateDefault(func(e *Engine) (*Engine, error) {
+		return e.WithLang(tag, langs...), nil
+	})
+}
+
+// AddMessages adds or overrides messages of the default engine, see Engine.WithMessages.
+// The default engine is left unchanged when a message is invalid.
+// It rebuilds the bundle of the default engine on every call, so it is meant to be called at init time.
+func AddMessages(tag language.Tag, msgs ...*i18n.Message) error {
+	return updateDefault(func(e *Engine) (*Engine, error) {
+		return e.WithMessages(tag, msgs...)
+	})
 }
 
+// GetBundle returns the bundle of the default engine, see Engine.Bundle.
 func GetBundle() *i18n.Bundle {
-	return _bundle
+	return Default().Bundle()
 }
 
 func GetLocalizer() *i18n.Localizer {
-	return _localizer
+	return Default().Localizer()
 }
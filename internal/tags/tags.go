@@ -0,0 +1,209 @@
+// Package tags parses the validate struct tag. It is shared by validator.Struct and cmd/validator-gen,
+// so that both accept exactly the same rules.
+package tags
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Kind the kind of field a rule is applied to
+type Kind uint8
+
+const (
+	String  Kind = iota // ~string, validator.String
+	Number              // integers and floats, validator.Ordered
+	Slice               // slices and arrays, validator.Slice or validator.SliceOf
+	Pointer             // pointers, validator.Pointer
+)
+
+func (k Kind) String() string {
+	switch k {
+	case String:
+		return "string"
+	case Number:
+		return "number"
+	case Slice:
+		return "slice"
+	default:
+		return "pointer"
+	}
+}
+
+// ArgType the type of rule arguments
+type ArgType uint8
+
+const (
+	None   ArgType = iota
+	Int            // length, such as gte=3 on a string
+	Value          // same type as the field or the element
+	Regexp         // the whole param is a regular expression
+)
+
+// Spec describes how a rule maps to a builder method
+type Spec struct {
+	Method string  // builder method name
+	Arity  int     // number of arguments, -1 means any
+	Arg    ArgType // argument type
+}
+
+// Rule a rule of the validate tag, such as gte=18
+type Rule struct {
+	Name  string // lower-cased rule name
+	Param string // text after '='
+}
+
+var (
+	required = Spec{Method: "Required"}
+
+	lengths = map[string]Spec{
+		"required": required,
+		"eq":       {Method: "Eq", Arity: 1, Arg: Int},
+		"gt":       {Method: "Gt", Arity: 1, Arg: Int},
+		"gte":      {Method: "Gte", Arity: 1, Arg: Int},
+		"lt":       {Method: "Lt", Arity: 1, Arg: Int},
+		"lte":      {Method: "Lte", Arity: 1, Arg: Int},
+	}
+
+	specs = map[Kind]map[string]Spec{
+		String: merge(lengths, map[string]Spec{
+			"in":                     {Method: "In", Arity: -1, Arg: Value},
+			"between":                {Method: "Between", Arity: 2, Arg: Value},
+			"matchstring":            {Method: "MatchString", Arity: 1, Arg: Regexp},
+			"ipv4":                   {Method: "IPv4"},
+			"ipv6":                   {Method: "IPv6"},
+			"url":                    {Method: "URL"},
+			"email":                  {Method: "Email"},
+			"alphabet":               {Method: "Alphabet"},
+			"numeric":                {Method: "Numeric"},
+			"alphabetnumeric":        {Method: "AlphabetNumeric"},
+			"base64":                 {Method: "Base64"},
+			"hex":                    {Method: "Hex"},
+			"lowercase":              {Method: "Lowercase"},
+			"uppercase":              {Method: "Uppercase"},
+			"noleadingtrailingspace": {Method: "NoLeadingTrailingSpace"},
+			"runeleneq":              {Method: "RuneLenEq", Arity: 1, Arg: Int},
+			"runelengt":              {Method: "RuneLenGt", Arity: 1, Arg: Int},
+			"runelengte":             {Method: "RuneLenGte", Arity: 1, Arg: Int},
+			"runelenlt":              {Method: "RuneLenLt", Arity: 1, Arg: Int},
+			"runelenlte":             {Method: "RuneLenLte", Arity: 1, Arg: Int},
+			"charleneq":              {Method: "CharLenEq", Arity: 1, Arg: Int},
+			"charlengt":              {Method: "CharLenGt", Arity: 1, Arg: Int},
+			"charlengte":             {Method: "CharLenGte", Arity: 1, Arg: Int},
+			"charlenlt":              {Method: "CharLenLt", Arity: 1, Arg: Int},
+			"charlenlte":             {Method: "CharLenLte", Arity: 1, Arg: Int},
+		}),
+		Number: {
+			"required": required,
+			"gt":       {Method: "Gt", Arity: 1, Arg: Value},
+			"gte":      {Method: "Gte", Arity: 1, Arg: Value},
+			"lt":       {Method: "Lt", Arity: 1, Arg: Value},
+			"lte":      {Method: "Lte", Arity: 1, Arg: Value},
+			"between":  {Method: "Between", Arity: 2, Arg: Value},
+			"in":       {Method: "In", Arity: -1, Arg: Value},
+		},
+		Slice: merge(lengths, map[string]Spec{
+			"contains":    {Method: "Contains", Arity: 1, Arg: Value},
+			"notcontains": {Method: "NotContains", Arity: 1, Arg: Value},
+			"containsall": {Method: "ContainsAll", Arity: -1, Arg: Value},
+			"containsany": {Method: "ContainsAny", Arity: -1, Arg: Value},
+			"subsetof":    {Method: "SubsetOf", Arity: -1, Arg: Value},
+			"unique":      {Method: "Unique"},
+			"sorted":      {Method: "Sorted"},
+			"sorteddesc":  {Method: "SortedDesc"},
+		}),
+		Pointer: {
+			"required": required,
+		},
+	}
+)
+
+func merge(a, b map[string]Spec) map[string]Spec {
+	var m = make(map[string]Spec, len(a)+len(b))
+	for k, v := range a {
+		m[k] = v
+	}
+	for k, v := range b {
+		m[k] = v
+	}
+	return m
+}
+
+// Parse splits the tag into rules, rule names are case-insensitive.
+// A comma inside an argument is written as \, such as matchstring=^a{1\,3}$.
+func Parse(tag string) ([]Rule, error) {
+	var rules []Rule
+	for _, item := range split(tag) {
+		name, param, _ := strings.Cut(strings.TrimSpace(item), "=")
+		if name == "" {
+			return nil, errors.New("empty rule")
+		}
+		if !isName(name) {
+			return nil, fmt.Errorf("invalid rule name %q, write a comma inside an argument as \\,", name)
+		}
+		rules = append(rules, Rule{Name: strings.ToLower(name), Param: param})
+	}
+	return rules, nil
+}
+
+// split 按逗号拆分规则, \, 表示参数中的逗号
+func split(tag string) []string {
+	var items []string
+	var sb strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == ',':
+			sb.WriteByte(',')
+			i++
+		case tag[i] == ',':
+			items = append(items, sb.String())
+			sb.Reset()
+		default:
+			sb.WriteByte(tag[i])
+		}
+	}
+	return append(items, sb.String())
+}
+
+// isName 规则名只包含字母和数字
+func isName(name string) bool {
+	for _, r := range name {
+		if !('a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9') {
+			return false
+		}
+	}
+	return true
+}
+
+// Lookup finds the spec of the rule for a field kind, splits the param into arguments and checks the arity.
+// Int arguments are checked here, Value arguments depend on the field type and are left to the caller.
+func Lookup(kind Kind, r Rule) (Spec, []string, error) {
+	spec, ok := specs[kind][r.Name]
+	if !ok {
+		return spec, nil, fmt.Errorf("unknown rule %s for %s", r.Name, kind)
+	}
+	var args []string
+	switch spec.Arg {
+	case None:
+		if r.Param != "" {
+			return spec, nil, fmt.Errorf("rule %s: expected no arguments", r.Name)
+		}
+	case Regexp:
+		args = []string{r.Param}
+	default:
+		args = strings.Fields(r.Param)
+	}
+	if spec.Arity >= 0 && len(args) != spec.Arity {
+		return spec, nil, fmt.Errorf("rule %s: expected %d arguments, got %d", r.Name, spec.Arity, len(args))
+	}
+	if spec.Arg == Int {
+		for _, item := range args {
+			if _, err := strconv.Atoi(item); err != nil {
+				return spec, nil, fmt.Errorf("rule %s: %v", r.Name, err)
+			}
+		}
+	}
+	return spec, args, nil
+}
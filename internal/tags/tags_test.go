@@ -0,0 +1,52 @@
+package tags
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	rules, err := Parse("required, gte=18,MatchString=^[a-z]+$")
+	assert.NoError(t, err)
+	assert.Equal(t, []Rule{{Name: "required"}, {Name: "gte", Param: "18"}, {Name: "matchstring", Param: "^[a-z]+$"}}, rules)
+
+	_, err = Parse("required,")
+	assert.Error(t, err)
+
+	t.Run("comma", func(t *testing.T) {
+		rules, err := Parse(`required,matchstring=^a{1\,3}$,lte=3`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Rule{{Name: "required"}, {Name: "matchstring", Param: "^a{1,3}$"}, {Name: "lte", Param: "3"}}, rules)
+
+		rules, err = Parse(`matchstring=^\d\\$`)
+		assert.NoError(t, err)
+		assert.Equal(t, []Rule{{Name: "matchstring", Param: `^\d\\$`}}, rules)
+
+		_, err = Parse("matchstring=^a{1,3}$")
+		assert.ErrorContains(t, err, `invalid rule name "3}$"`)
+	})
+}
+
+func TestLookup(t *testing.T) {
+	spec, args, err := Lookup(String, Rule{Name: "between", Param: "a  z"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Between", spec.Method)
+	assert.Equal(t, []string{"a", "z"}, args)
+
+	spec, args, err = Lookup(String, Rule{Name: "matchstring", Param: "^a b$"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"^a b$"}, args)
+
+	_, _, err = Lookup(Number, Rule{Name: "email"})
+	assert.EqualError(t, err, "unknown rule email for number")
+	_, _, err = Lookup(Slice, Rule{Name: "gte", Param: "a"})
+	assert.Error(t, err)
+	_, _, err = Lookup(Pointer, Rule{Name: "required", Param: "1"})
+	assert.Error(t, err)
+	_, _, err = Lookup(Number, Rule{Name: "between", Param: "1"})
+	assert.Error(t, err)
+
+	assert.Equal(t, "string", String.String())
+	assert.Equal(t, "slice", Slice.String())
+	assert.Equal(t, "pointer", Pointer.String())
+}
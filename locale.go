@@ -0,0 +1,85 @@
+package validator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/BurntSushi/toml"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+)
+
+// unmarshalFuncs 支持的语言文件格式, 按扩展名区分
+var unmarshalFuncs = map[string]i18n.UnmarshalFunc{
+	"json": json.Unmarshal,
+	"toml": toml.Unmarshal,
+	"yaml": yaml.Unmarshal,
+	"yml":  yaml.Unmarshal,
+}
+
+// locales 一个语言的消息, 来自语言文件或 WithMessages, 重建引擎时依次添加
+type locales struct {
+	tag  language.Tag
+	msgs []*i18n.Message
+}
+
+// LoadLocales adds or overrides messages of the default engine with the files of fsys matching glob,
+// see Engine.WithLocales. The default engine is left unchanged when a file cannot be loaded.
+// Like AddMessages, it rebuilds the bundle of the default engine and is meant to be called at init time.
+func LoadLocales(fsys fs.FS, glob string) error {
+	return updateDefault(func(e *Engine) (*Engine, error) {
+		return e.WithLocales(fsys, glob)
+	})
+}
+
+// WithLocales returns a copy of the engine with the messages of the files of fsys matching glob,
+// such as WithLocales(os.DirFS("locales"), "*.toml").
+// The language and the format come from the file name, such as active.ja-JP.json, de-DE.toml or fr-FR.yaml.
+// Messages of later files override earlier ones. The files are read once, the messages loaded before
+// and the messages added with WithMessages are kept, messages added to Bundle directly are not.
+func (e *Engine) WithLocales(fsys fs.FS, glob string) (*Engine, error) {
+	files, err := readLocales(fsys, glob)
+	if err != nil {
+		return nil, err
+	}
+	var sources = append(e.sources[:len(e.sources):len(e.sources)], files...)
+	return newEngine(e.tag, e.langs, sources)
+}
+
+// readLocales 解析匹配的语言文件, 任何文件出错都返回错误
+func readLocales(fsys fs.FS, glob string) ([]locales, error) {
+	matches, err := fs.Glob(fsys, glob)
+	if err != nil {
+		return nil, fmt.Errorf("validator: load locales %s: %w", glob, err)
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("validator: load locales %s: no files match", glob)
+	}
+	var list = make([]locales, 0, len(matches))
+	for _, path := range matches {
+		src, err := readLocale(fsys, path)
+		if err != nil {
+			return nil, fmt.Errorf("validator: load locale %s: %w", path, err)
+		}
+		list = append(list, src)
+	}
+	return list, nil
+}
+
+// readLocale 解析单个语言文件, 文件名中必须包含语言
+func readLocale(fsys fs.FS, path string) (locales, error) {
+	buf, err := fs.ReadFile(fsys, path)
+	if err != nil {
+		return locales{}, err
+	}
+	file, err := i18n.ParseMessageFileBytes(buf, path, unmarshalFuncs)
+	if err != nil {
+		return locales{}, err
+	}
+	if file.Tag == language.Und {
+		return locales{}, errors.New("no language in the file name")
+	}
+	return locales{tag: file.Tag, msgs: file.Messages}, nil
+}
@@ -0,0 +1,115 @@
+package validator
+
+import (
+	"encoding/json"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEngine_WithLocales(t *testing.T) {
+	var fsys = fstest.MapFS{
+		"locales/active.ja-JP.json": {Data: []byte(`{"StringValue.Required": "{{.Key}} は必須です"}`)},
+		"locales/de-DE.toml":        {Data: []byte(`"StringValue.Required" = "{{.Key}} darf nicht leer sein"`)},
+		"locales/fr-FR.yaml":        {Data: []byte(`StringValue.Required: "{{.Key}} ne peut pas être vide"`)},
+		"override/en-US.yml":        {Data: []byte(`StringValue.Required: "{{.Key}} is required"`)},
+		"bad/ja-JP.json":            {Data: []byte(`{"StringValue.Required": `)},
+		"bad/messages.json":         {Data: []byte(`{"StringValue.Required": "aha"}`)},
+		"bad/ja-JP.ini":             {Data: []byte(`StringValue.Required = aha`)},
+	}
+
+	t.Run("", func(t *testing.T) {
+		e, err := NewEngine(English).WithLocales(fsys, "locales/*")
+		assert.NoError(t, err)
+		var value = func() Valuer { return String("name", "").Required() }
+		assert.Equal(t, "name は必須です", e.NewValidator(newReq("ja-JP")).Validate(value()).Error())
+		assert.Equal(t, "name darf nicht leer sein", e.NewValidator(newReq("de")).Validate(value()).Error())
+		assert.Equal(t, "name ne peut pas être vide", e.NewValidator(newReq("fr-FR")).Validate(value()).Error())
+		assert.Equal(t, "name 不能为空", e.NewValidator(newReq("zh-CN")).Validate(value()).Error())
+		assert.Equal(t, "name cannot be empty", e.Validate(value()).Error())
+
+		e, err = e.WithLocales(fsys, "override/*.yml")
+		assert.NoError(t, err)
+		assert.Equal(t, "name is required", e.Validate(value()).Error())
+		assert.Equal(t, "name は必須です", e.NewValidator(newReq("ja-JP")).Validate(value()).Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var e = NewEngine(English)
+		_, err := e.WithLocales(fsys, "bad/ja-JP.json")
+		assert.ErrorContains(t, err, "bad/ja-JP.json")
+		_, err = e.WithLocales(fsys, "bad/messages.json")
+		assert.ErrorContains(t, err, "no language")
+		_, err = e.WithLocales(fsys, "bad/*.ini")
+		assert.ErrorContains(t, err, "no unmarshaler")
+		_, err = e.WithLocales(fsys, "missing/*.json")
+		assert.ErrorContains(t, err, "no files match")
+		_, err = e.WithLocales(fsys, "[")
+		assert.Error(t, err)
+	})
+}
+
+func TestLoadLocales(t *testing.T) {
+	var previous = Default()
+	defer SetDefault(previous)
+
+	var dir = t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "active.en-US.json"), []byte(`{"StringValue.Required": "{{.Key}} is required"}`), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "active.ko-KR.json"), []byte(`{"StringValue.Required": `), 0644))
+
+	assert.Error(t, LoadLocales(os.DirFS(dir), "*.json"))
+	assert.Equal(t, previous, Default())
+
+	assert.NoError(t, LoadLocales(os.DirFS(dir), "*.en-US.json"))
+	assert.Equal(t, "name is required", String("name", "").Required().Err().Error())
+
+	t.Run("keep messages", func(t *testing.T) {
+		assert.NoError(t, AddMessages(Chinese, &i18n.Message{ID: "Field.name", Other: "名称"}))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "active.ja-JP.json"), []byte(`{"StringValue.Required": "{{.Key}} は必須です"}`), 0644))
+		assert.NoError(t, LoadLocales(os.DirFS(dir), "*.ja-JP.json"))
+		assert.NoError(t, os.Remove(filepath.Join(dir, "active.ja-JP.json")))
+
+		SetLang(Chinese, Chinese.String())
+		assert.Equal(t, "名称 不能为空", String("name", "").Required().Err().Error())
+		assert.Equal(t, "名称 is required", NewValidator(newReq("en-US")).Validate(String("name", "").Required()).Error())
+		assert.Equal(t, "名称 は必須です", NewValidator(newReq("ja-JP")).Validate(String("name", "").Required()).Error())
+	})
+}
+
+func TestAssets(t *testing.T) {
+	var messages = func(path string) map[string]string {
+		var m map[string]string
+		buf, err := assetFS.ReadFile(path)
+		assert.NoError(t, err)
+		assert.NoError(t, json.Unmarshal(buf, &m))
+		return m
+	}
+	var en = messages("asset/active.en-US.json")
+	for _, tag := range []language.Tag{Chinese, Japanese, Korean, German, French, Spanish, Portuguese, TraditionalChinese} {
+		var m = messages("asset/active." + tag.String() + ".json")
+		assert.Equal(t, len(en), len(m), tag.String())
+		var loc = i18n.NewLocalizer(GetBundle(), tag.String())
+		for id := range en {
+			assert.NotEmpty(t, m[id], id)
+			str, err := loc.Localize(&i18n.LocalizeConfig{
+				MessageID:    id,
+				TemplateData: map[string]any{"Key": "name", "Other": "other", "Arg0": 1, "Arg1": 2},
+			})
+			assert.NoError(t, err)
+			assert.Equal(t, strings.NewReplacer("{{.Key}}", "name", "{{.Other}}", "other", "{{.Arg0}}", "1", "{{.Arg1}}", "2").Replace(m[id]), str)
+		}
+	}
+
+	t.Run("", func(t *testing.T) {
+		var value = func() Valuer { return String("name", "").Required() }
+		assert.Equal(t, "name は必須です", NewValidator(newReq("ja")).Validate(value()).Error())
+		assert.Equal(t, "name 不能為空", NewValidator(newReq("zh-TW")).Validate(value()).Error())
+		assert.Equal(t, "name 不能为空", NewValidator(newReq("zh-CN")).Validate(value()).Error())
+		assert.Equal(t, "name não pode estar vazio", NewValidator(newReq("pt-BR,pt;q=0.9")).Validate(value()).Error())
+	})
+}
@@ -0,0 +1,204 @@
+package validator
+
+import (
+	"cmp"
+	"fmt"
+	"golang.org/x/text/language"
+	"slices"
+)
+
+type MapValue[K cmp.Ordered, V any] struct {
+	err error
+	core
+	val    map[K]V
+	values []Valuer
+}
+
+func Map[K cmp.Ordered, V any](k string, v map[K]V) *MapValue[K, V] {
+	return &MapValue[K, V]{
+		core: newCore(k),
+		val:  v,
+	}
+}
+
+func (c *MapValue[K, V]) setPrefix(prefix string) {
+	c.key = joinKey(prefix, c.key)
+	for _, item := range c.values {
+		item.setPrefix(prefix)
+	}
+}
+
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *MapValue[K, V]) All() *MapValue[K, V] {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *MapValue[K, V]) Label(id string) *MapValue[K, V] {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *MapValue[K, V]) Msg(text string) *MapValue[K, V] {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *MapValue[K, V]) Msgs(texts map[language.Tag]string) *MapValue[K, V] {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *MapValue[K, V]) MsgID(id string) *MapValue[K, V] {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *MapValue[K, V]) When(cond bool) *MapValue[K, V] {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *MapValue[K, V]) Unless(cond bool) *MapValue[K, V] {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *MapValue[K, V]) Optional() *MapValue[K, V] {
+	c.setOptional(len(c.val) == 0)
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *MapValue[K, V]) RequiredIf(other string, otherVal, want any) *MapValue[K, V] {
+	c.requiredIf(len(c.val) > 0, other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *MapValue[K, V]) RequiredWith(other string, otherVal any) *MapValue[K, V] {
+	c.requiredWith(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *MapValue[K, V]) RequiredWithout(other string, otherVal any) *MapValue[K, V] {
+	c.requiredWithout(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *MapValue[K, V]) ExcludedWith(other string, otherVal any) *MapValue[K, V] {
+	c.excludedWith(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+func (c *MapValue[K, V]) validate(messageId string, ok bool, args ...any) *MapValue[K, V] {
+	c.check(messageId, ok, args...)
+	return c
+}
+
+// Err get error
+func (c *MapValue[K, V]) Err() error {
+	if c.err != nil {
+		return c.err
+	}
+	c.err = composeError(c.conf, c.key, c.label, c.val, c.locConfs, c.values, c.all || c.conf.all)
+	return c.err
+}
+
+// sortedKeys 按顺序遍历, 保证错误信息稳定
+func (c *MapValue[K, V]) sortedKeys() []K {
+	var keys = make([]K, 0, len(c.val))
+	for k := range c.val {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
+
+// Required the map cannot be empty
+func (c *MapValue[K, V]) Required() *MapValue[K, V] {
+	return c.validate("MapValue.Required", len(c.val) > 0)
+}
+
+// Eq check the map length is equal to v
+func (c *MapValue[K, V]) Eq(v int) *MapValue[K, V] {
+	return c.validate("MapValue.Eq", len(c.val) == v, v)
+}
+
+// Gt check the map length is greater than v
+func (c *MapValue[K, V]) Gt(v int) *MapValue[K, V] {
+	return c.validate("MapValue.Gt", len(c.val) > v, v)
+}
+
+// Gte check the map length is greater or equal than v
+func (c *MapValue[K, V]) Gte(v int) *MapValue[K, V] {
+	return c.validate("MapValue.Gte", len(c.val) >= v, v)
+}
+
+// Lt check the map length is less than v
+func (c *MapValue[K, V]) Lt(v int) *MapValue[K, V] {
+	return c.validate("MapValue.Lt", len(c.val) < v, v)
+}
+
+// Lte check the map length is less or equal than v
+func (c *MapValue[K, V]) Lte(v int) *MapValue[K, V] {
+	return c.validate("MapValue.Lte", len(c.val) <= v, v)
+}
+
+// HasKey checks whether the map contains the key k
+func (c *MapValue[K, V]) HasKey(k K) *MapValue[K, V] {
+	_, ok := c.val[k]
+	return c.validate("MapValue.HasKey", ok, k)
+}
+
+// KeysIn check if args contains every key of the map, the first key not allowed is reported.
+func (c *MapValue[K, V]) KeysIn(args ...K) *MapValue[K, V] {
+	for _, k := range c.sortedKeys() {
+		if !contains(args, k) {
+			return c.validate("MapValue.KeysIn", false, k, args)
+		}
+	}
+	return c.validate("MapValue.KeysIn", true)
+}
+
+// EachKey validates every key with the Valuer returned by f, errors are reported as key[k].
+func (c *MapValue[K, V]) EachKey(f func(k K) Valuer) *MapValue[K, V] {
+	for _, k := range c.sortedKeys() {
+		c.addValue(k, f(k))
+	}
+	return c
+}
+
+// EachValue validates every value with the Valuer returned by f, errors are reported as key[k].
+func (c *MapValue[K, V]) EachValue(f func(v V) Valuer) *MapValue[K, V] {
+	for _, k := range c.sortedKeys() {
+		c.addValue(k, f(c.val[k]))
+	}
+	return c
+}
+
+func (c *MapValue[K, V]) addValue(k K, value Valuer) {
+	if c.skipped() {
+		return
+	}
+	value.setPrefix(c.key + "[" + fmt.Sprint(k) + "]")
+	c.values = append(c.values, value)
+}
+
+func (c *MapValue[K, V]) Customize(messageId string, f func(map[K]V) bool) *MapValue[K, V] {
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
+}
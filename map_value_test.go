@@ -0,0 +1,121 @@
+package validator
+
+import (
+	"errors"
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"testing"
+)
+
+func TestMapValue_Required(t *testing.T) {
+	var m1 = map[string]string{"env": "prod"}
+	var m2 map[string]string
+	assert.Nil(t, Map("labels", m1).Required().Err())
+	assert.Error(t, Map("labels", m2).Required().Err())
+	assert.Error(t, Map("labels", m2).Gt(4).Required().Err())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(
+			Map("labels", m2).Required(),
+		)
+		assert.Equal(t, "labels 不能为空", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var value = Map("labels", m2).Required()
+		value.locConf.MessageID = "aha"
+		assert.Error(t, value.Err())
+		assert.Error(t, value.Err())
+	})
+}
+
+func TestMapValue_Length(t *testing.T) {
+	var m = map[string]int{"a": 1, "b": 2}
+	assert.Nil(t, Map("m", m).Eq(2).Gt(1).Gte(2).Lt(3).Lte(2).Err())
+	assert.Equal(t, "m length must equal 3", Map("m", m).Eq(3).Err().Error())
+	assert.Error(t, Map("m", m).Gt(2).Err())
+	assert.Error(t, Map("m", m).Gte(3).Err())
+	assert.Error(t, Map("m", m).Lt(2).Err())
+	assert.Error(t, Map("m", m).Lte(1).Err())
+}
+
+func TestMapValue_Keys(t *testing.T) {
+	var m = map[string]string{"env": "prod", "app": "api", "zone": "a"}
+	assert.Nil(t, Map("labels", m).HasKey("env").Err())
+	assert.Equal(t, "labels must contain the key team", Map("labels", m).HasKey("team").Err().Error())
+	assert.Nil(t, Map("labels", m).KeysIn("env", "app", "zone").Err())
+	assert.Equal(t,
+		"labels has the key app, keys must be included in [env zone]",
+		Map("labels", m).KeysIn("env", "zone").Err().Error(),
+	)
+}
+
+func TestMapValue_Each(t *testing.T) {
+	var m = map[string]string{"env": "prod", "App": "", "zone": "a"}
+
+	t.Run("", func(t *testing.T) {
+		var err = Map("labels", m).
+			EachKey(func(k string) Valuer { return String("", k).Lowercase() }).
+			EachValue(func(v string) Valuer { return String("", v).Required() }).
+			Err()
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, "labels[App]", fe.Key)
+		assert.Equal(t, "labels[App] must consist of lowercase letters only", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = Nested("meta", Map("labels", m).
+			HasKey("team").
+			EachValue(func(v string) Valuer { return String("", v).Required() }),
+		).Err()
+		assert.Equal(t, "meta.labels must contain the key team", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).ValidateAll(
+			Nested("meta", Map("labels", m).
+				HasKey("team").
+				EachKey(func(k string) Valuer { return String("", k).Lowercase() }).
+				EachValue(func(v string) Valuer { return String("", v).Required() })),
+		)
+		var list = err.(Errors)
+		assert.Equal(t, 3, len(list))
+		assert.Equal(t, "meta.labels 须包含键team", list[0].Error())
+		assert.Equal(t, "meta.labels[App] 须由小写字母组成", list[1].Error())
+		assert.Equal(t, "meta.labels[App] 不能为空", list[2].Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = Map("ports", map[int]int{80: 8080}).
+			All().
+			Lt(1).
+			EachValue(func(v int) Valuer { return Ordered("", v).Lte(1024) }).
+			Err()
+		assert.Equal(t, "ports length must be less than 1; ports[80] must be less than or equal to 1024", err.Error())
+		assert.Nil(t, Map("ports", map[int]int{80: 80}).EachValue(func(v int) Valuer { return Ordered("", v).Lte(1024) }).Err())
+	})
+}
+
+func TestMapValue_Customize(t *testing.T) {
+	_ = GetBundle().AddMessages(language.Make("en-US"), &i18n.Message{
+		ID:    "Customize",
+		Other: "未成年人禁止入内",
+	})
+	var m = map[string]int{"a": 1}
+	assert.Equal(t, "未成年人禁止入内", Map("m", m).Customize("Customize", func(m map[string]int) bool {
+		return len(m) > 1
+	}).Err().Error())
+	assert.Nil(t, Map("m", m).Customize("Customize", func(m map[string]int) bool {
+		return len(m) == 1
+	}).Err())
+}
+
+func TestMapValue_When(t *testing.T) {
+	var m = map[string]int{"a": 0}
+	assert.Nil(t, Map("scores", m).When(false).HasKey("b").EachValue(func(v int) Valuer { return Ordered("", v).Required() }).Err())
+	assert.Error(t, Map("scores", m).When(true).HasKey("b").Err())
+	assert.Error(t, Map("scores", m).ExcludedWith("total", 1).Err())
+	assert.Error(t, Map[string, int]("scores", nil).RequiredWithout("total", 0).Err())
+}
@@ -0,0 +1,84 @@
+package validator
+
+import "strconv"
+
+// NestedValue groups the values of a child object, their keys are prefixed with the key of the group.
+type NestedValue struct {
+	err    error
+	key    string
+	all    bool
+	skip   bool
+	conf   *config
+	values []Valuer
+}
+
+// Nested validates a child object, the keys of values are reported as key.childKey, such as address.city.
+func Nested(k string, values ...Valuer) *NestedValue {
+	for _, item := range values {
+		item.setPrefix(k)
+	}
+	return &NestedValue{
+		key:    k,
+		conf:   defaultConf(),
+		values: values,
+	}
+}
+
+// Each validates every element of s with the values returned by f,
+// the keys are reported as key[i].childKey, such as Items[2].Name.
+func Each[T any](k string, s []T, f func(i int, elem T) []Valuer) *NestedValue {
+	var values []Valuer
+	for i, elem := range s {
+		var prefix = k + "[" + strconv.Itoa(i) + "]"
+		for _, item := range f(i, elem) {
+			item.setPrefix(prefix)
+			values = append(values, item)
+		}
+	}
+	return &NestedValue{
+		key:    k,
+		conf:   defaultConf(),
+		values: values,
+	}
+}
+
+func (c *NestedValue) setConf(conf *config) {
+	c.conf = conf
+}
+
+func (c *NestedValue) setPrefix(prefix string) {
+	c.key = joinKey(prefix, c.key)
+	for _, item := range c.values {
+		item.setPrefix(prefix)
+	}
+}
+
+// All keep checking the children after the first failure, Err reports every failed child.
+func (c *NestedValue) All() *NestedValue {
+	c.all = true
+	return c
+}
+
+// When validates the children only if cond is true.
+func (c *NestedValue) When(cond bool) *NestedValue {
+	c.skip = !cond
+	return c
+}
+
+// Unless validates the children only if cond is false.
+func (c *NestedValue) Unless(cond bool) *NestedValue {
+	c.skip = cond
+	return c
+}
+
+// Err get error
+func (c *NestedValue) Err() error {
+	if c.skip {
+		return nil
+	}
+	if c.err != nil {
+		return c.err
+	}
+	c.err = validateValues(c.conf, c.values, c.all || c.conf.all)
+	return c.err
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNested(t *testing.T) {
+	type Address struct {
+		City string
+	}
+	type Item struct {
+		Name  string
+		Count int
+	}
+
+	t.Run("", func(t *testing.T) {
+		var addr = Address{}
+		var err = Nested("address", String("city", addr.City).Required()).Err()
+		assert.Equal(t, "address.city cannot be empty", err.Error())
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, "address.city", fe.Key)
+	})
+
+	t.Run("", func(t *testing.T) {
+		var items = []Item{{Name: "a", Count: 1}, {Name: "b"}, {Count: 1}}
+		var each = func(i int, item Item) []Valuer {
+			return []Valuer{
+				String("Name", item.Name).Required(),
+				Ordered("Count", item.Count).Gt(0),
+			}
+		}
+		assert.Equal(t, "Items[1].Count must be greater than 0", Each("Items", items, each).Err().Error())
+
+		var err = NewValidator(newReq("zh-CN")).ValidateAll(
+			Nested("order", Each("Items", items, each), Ordered("ID", 0).Required()),
+		)
+		var list = err.(Errors)
+		assert.Equal(t, 3, len(list))
+		assert.Equal(t, "order.Items[1].Count 须大于0", list[0].Error())
+		assert.Equal(t, "order.Items[2].Name 不能为空", list[1].Error())
+		assert.Equal(t, "order.ID 不能为空", list[2].Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var value = Nested("tags",
+			Slice("", []string{}).Required(),
+			Pointer[int]("[0]", nil).Required(),
+			Any("", 1).Customize("AnyValue.Customize", func(int) bool { return false }),
+		).All()
+		assert.Equal(t, "tags cannot be empty; tags[0] cannot be empty; tags validation failed", value.Err().Error())
+		assert.Nil(t, Nested("address", String("city", "x").Required()).Err())
+	})
+}
+
+func TestStruct_Nested(t *testing.T) {
+	type Address struct {
+		City string `json:"city" validate:"required"`
+	}
+	type Item struct {
+		Name string `json:"name" validate:"required"`
+	}
+	type Req struct {
+		Address  Address  `json:"address"`
+		Backup   *Address `json:"backup"`
+		Items    []Item   `json:"items"`
+		Pointers []*Item  `json:"pointers"`
+		Ignored  Address  `validate:"-"`
+		Empty    struct{}
+	}
+
+	t.Run("", func(t *testing.T) {
+		var req = Req{Address: Address{City: "x"}, Items: []Item{{Name: "a"}}}
+		assert.Nil(t, Struct(req).Err())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var req = Req{
+			Backup:   &Address{},
+			Items:    []Item{{Name: "a"}, {}},
+			Pointers: []*Item{nil, {}},
+		}
+		var list = ValidateAll(Struct(req)).(Errors)
+		assert.Equal(t, 4, len(list))
+		assert.Equal(t, "address.city cannot be empty", list[0].Error())
+		assert.Equal(t, "backup.city cannot be empty", list[1].Error())
+		assert.Equal(t, "items[1].name cannot be empty", list[2].Error())
+		assert.Equal(t, "pointers[1].name cannot be empty", list[3].Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = Nested("body", Struct(Req{})).Err()
+		assert.Equal(t, "body.address.city cannot be empty", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var cases = []any{
+			struct {
+				A Address `validate:"required"`
+			}{},
+			struct {
+				A []Item `validate:"sorted"`
+			}{},
+			struct {
+				A *Address `validate:"required,gte=1"`
+			}{},
+			struct {
+				A []struct {
+					B string `validate:"email,"`
+				}
+			}{A: []struct {
+				B string `validate:"email,"`
+			}{{}}},
+		}
+		for _, item := range cases {
+			assert.True(t, errors.Is(Struct(item).Err(), ErrInvalidTag))
+		}
+	})
+}
+
+func TestNested_When(t *testing.T) {
+	assert.Nil(t, Nested("address", String("city", "").Required()).When(false).Err())
+	assert.Error(t, Nested("address", String("city", "").Required()).Unless(false).Err())
+	assert.Nil(t, Nested("address", String("city", "").Required()).Unless(true).Err())
+}
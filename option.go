@@ -2,30 +2,45 @@ package validator
 
 import (
 	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
 	"net/http"
 )
 
 type config struct {
-	loc *i18n.Localizer
+	loc  *i18n.Localizer
+	tags []language.Tag // 用户请求的语言, 用于选择 Msgs 的文本
+	all  bool           // 复合校验器收集全部错误
 }
 
 type Option func(c *config)
 
 // withLang set languages
-func withLang(r *http.Request) Option {
+func withLang(bundle *i18n.Bundle, r *http.Request) Option {
 	return func(c *config) {
 		if r != nil {
 			lang := r.FormValue("lang")
 			accept := r.Header.Get("Accept-Language")
-			c.loc = i18n.NewLocalizer(GetBundle(), lang, accept)
+			c.loc = i18n.NewLocalizer(bundle, lang, accept)
+			c.tags = parseTags(lang, accept)
 		}
 	}
 }
 
-func withInit() Option {
+func withInit(loc *i18n.Localizer, tags []language.Tag) Option {
 	return func(c *config) {
 		if c.loc == nil {
-			c.loc = GetLocalizer()
+			c.loc = loc
+			c.tags = tags
 		}
 	}
 }
+
+// parseTags 按 i18n.NewLocalizer 的方式解析语言, 忽略无法解析的值
+func parseTags(langs ...string) []language.Tag {
+	var tags []language.Tag
+	for _, lang := range langs {
+		list, _, _ := language.ParseAcceptLanguage(lang)
+		tags = append(tags, list...)
+	}
+	return tags
+}
@@ -2,42 +2,25 @@ package validator
 
 import (
 	"cmp"
-	"errors"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
-	"strconv"
+	"golang.org/x/text/language"
 )
 
 type OrderedValue[T cmp.Ordered] struct {
-	err     error
-	key     string
-	val     T
-	mark    bool
-	conf    *config
-	locConf *i18n.LocalizeConfig
+	err error
+	core
+	val T
+	ref *T
 }
 
 func Ordered[T cmp.Ordered](k string, v T) *OrderedValue[T] {
 	return &OrderedValue[T]{
-		key:  k,
+		core: newCore(k),
 		val:  v,
-		conf: _conf,
 	}
 }
 
 func (c *OrderedValue[T]) validate(messageId string, ok bool, args ...any) *OrderedValue[T] {
-	if c.mark || ok {
-		return c
-	}
-	c.mark = true
-	td := map[string]any{"Key": c.key}
-	for i, v := range args {
-		key := "Arg" + strconv.Itoa(i)
-		td[key] = v
-	}
-	c.locConf = &i18n.LocalizeConfig{
-		MessageID:    messageId,
-		TemplateData: td,
-	}
+	c.check(messageId, ok, args...)
 	return c
 }
 
@@ -49,22 +32,131 @@ func (c *OrderedValue[T]) Err() error {
 	if c.err != nil {
 		return c.err
 	}
-	str, err := c.conf.loc.Localize(c.locConf)
-	if err != nil {
-		c.err = err
-		return c.err
-	}
-	c.err = errors.New(str)
+	c.err = c.fieldError(c.val)
 	return c.err
 }
 
-func (c *OrderedValue[T]) setConf(conf *config) {
-	c.conf = conf
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *OrderedValue[T]) All() *OrderedValue[T] {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *OrderedValue[T]) Label(id string) *OrderedValue[T] {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *OrderedValue[T]) Msg(text string) *OrderedValue[T] {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *OrderedValue[T]) Msgs(texts map[language.Tag]string) *OrderedValue[T] {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *OrderedValue[T]) MsgID(id string) *OrderedValue[T] {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *OrderedValue[T]) When(cond bool) *OrderedValue[T] {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *OrderedValue[T]) Unless(cond bool) *OrderedValue[T] {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *OrderedValue[T]) Optional() *OrderedValue[T] {
+	c.setOptional(isZero(c.val))
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *OrderedValue[T]) RequiredIf(other string, otherVal, want any) *OrderedValue[T] {
+	c.requiredIf(!isZero(c.val), other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *OrderedValue[T]) RequiredWith(other string, otherVal any) *OrderedValue[T] {
+	c.requiredWith(!isZero(c.val), other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *OrderedValue[T]) RequiredWithout(other string, otherVal any) *OrderedValue[T] {
+	c.requiredWithout(!isZero(c.val), other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *OrderedValue[T]) ExcludedWith(other string, otherVal any) *OrderedValue[T] {
+	c.excludedWith(!isZero(c.val), other, otherVal)
+	return c
+}
+
+// OrderedRef validates *v like Ordered, Default and the transforms write the new value back to *v.
+func OrderedRef[T cmp.Ordered](k string, v *T) *OrderedValue[T] {
+	var c = Ordered(k, *v)
+	c.ref = v
+	return c
+}
+
+// Default sets the ordered value to v when it is zero, it must be called before the rules.
+// It is skipped like the rules after When(false) or Unless(true).
+func (c *OrderedValue[T]) Default(v T) *OrderedValue[T] {
+	if !c.skip && isZero(c.val) {
+		c.set(v)
+	}
+	return c
+}
+
+// set 更新值, 并写回引用, 重新判断可选字段是否为空
+func (c *OrderedValue[T]) set(v T) {
+	c.val = v
+	c.update(isZero(v))
+	if c.ref != nil {
+		*c.ref = v
+	}
+}
+
+// Value returns the ordered value checked by the rules, after transforms.
+func (c *OrderedValue[T]) Value() T {
+	return c.val
+}
+
+// Transform replaces the ordered value with f(value), the following rules check the new value.
+// It is skipped like the rules after When(false) or Unless(true).
+func (c *OrderedValue[T]) Transform(f func(T) T) *OrderedValue[T] {
+	if !c.skip {
+		c.set(f(c.val))
+	}
+	return c
+}
+
+// Clamp limits the ordered value to the range [lo, hi]
+func (c *OrderedValue[T]) Clamp(lo, hi T) *OrderedValue[T] {
+	return c.Transform(func(v T) T { return max(lo, min(v, hi)) })
 }
 
 // Required the ordered value cannot be empty
 func (c *OrderedValue[T]) Required() *OrderedValue[T] {
-	return c.validate("OrderedValue.Required", !isZero(c.val), nil)
+	return c.validate("OrderedValue.Required", !isZero(c.val))
 }
 
 // Gt check the ordered value is greater than v
@@ -97,9 +189,146 @@ func (c *OrderedValue[T]) In(args ...T) *OrderedValue[T] {
 	return c.validate("OrderedValue.In", contains(args, c.val), args)
 }
 
+// EqField check the ordered value is equal to the other field
+func (c *OrderedValue[T]) EqField(other string, v T) *OrderedValue[T] {
+	c.checkField("OrderedValue.EqField", c.val == v, other)
+	return c
+}
+
+// NeField check the ordered value is not equal to the other field
+func (c *OrderedValue[T]) NeField(other string, v T) *OrderedValue[T] {
+	c.checkField("OrderedValue.NeField", c.val != v, other)
+	return c
+}
+
+// GtField check the ordered value is greater than the other field
+func (c *OrderedValue[T]) GtField(other string, v T) *OrderedValue[T] {
+	c.checkField("OrderedValue.GtField", c.val > v, other)
+	return c
+}
+
+// GteField check the ordered value is greater or equal than the other field
+func (c *OrderedValue[T]) GteField(other string, v T) *OrderedValue[T] {
+	c.checkField("OrderedValue.GteField", c.val >= v, other)
+	return c
+}
+
+// LtField check the ordered value is less than the other field
+func (c *OrderedValue[T]) LtField(other string, v T) *OrderedValue[T] {
+	c.checkField("OrderedValue.LtField", c.val < v, other)
+	return c
+}
+
+// LteField check the ordered value is less or equal than the other field
+func (c *OrderedValue[T]) LteField(other string, v T) *OrderedValue[T] {
+	c.checkField("OrderedValue.LteField", c.val <= v, other)
+	return c
+}
+
+// AnyOf check that the ordered value passes at least one of the rule sets, such as IPv4 or IPv6.
+// The message lists the first broken rule of every set, empty sets and sets whose rules are all skipped are ignored.
+func (c *OrderedValue[T]) AnyOf(fs ...func(o *OrderedValue[T])) *OrderedValue[T] {
+	if c.done() {
+		return c.validate("OrderedValue.AnyOf", true)
+	}
+	var rules subRules
+	for _, f := range fs {
+		var v = c.clone()
+		f(v)
+		if v.invalid != nil {
+			c.fail(v.invalid)
+			return c
+		}
+		if !v.ran {
+			continue
+		}
+		if !v.mark {
+			return c.validate("OrderedValue.AnyOf", true, c.passed(f))
+		}
+		rules = append(rules, v.locConf)
+	}
+	if len(rules) == 0 {
+		c.ignore()
+		return c
+	}
+	return c.validate("OrderedValue.AnyOf", false, rules)
+}
+
+// AllOf check that the ordered value passes all the rule sets, the message lists every broken rule.
+// It is ignored when the sets are empty or all their rules are skipped.
+func (c *OrderedValue[T]) AllOf(fs ...func(o *OrderedValue[T])) *OrderedValue[T] {
+	if c.done() {
+		return c.validate("OrderedValue.AllOf", true)
+	}
+	var v = c.clone()
+	v.all = true
+	for _, f := range fs {
+		f(v)
+	}
+	if v.invalid != nil {
+		c.fail(v.invalid)
+		return c
+	}
+	if !v.ran {
+		c.ignore()
+		return c
+	}
+	if !v.mark {
+		return c.validate("OrderedValue.AllOf", true, c.passed(fs...))
+	}
+	return c.validate("OrderedValue.AllOf", false, subRules(v.locConfs))
+}
+
+// Not check that the ordered value breaks the rule set, the message lists the rules that passed.
+// A rule that cannot be checked, such as an invalid regular expression, breaks Not as well.
+// It is ignored when the set is empty or all its rules are skipped.
+func (c *OrderedValue[T]) Not(f func(o *OrderedValue[T])) *OrderedValue[T] {
+	if c.done() {
+		return c.validate("OrderedValue.Not", true)
+	}
+	var v = c.clone()
+	f(v)
+	if v.invalid != nil {
+		c.fail(v.invalid)
+		return c
+	}
+	if !v.ran {
+		c.ignore()
+		return c
+	}
+	if v.mark {
+		return c.validate("OrderedValue.Not", true, subRules(v.locConfs))
+	}
+	v = c.clone()
+	v.invert = true
+	return c.validate("OrderedValue.Not", false, v.passed(f))
+}
+
+// passed 反转校验结果, 收集通过的规则, 用于 Not 的信息; 不在 Not 中时不需要
+func (c *OrderedValue[T]) passed(fs ...func(o *OrderedValue[T])) subRules {
+	if !c.invert {
+		return nil
+	}
+	var v = c.clone()
+	v.all = true
+	v.invert = true
+	for _, f := range fs {
+		f(v)
+	}
+	return subRules(v.locConfs)
+}
+
+// clone 复制键和值, 用于组合规则
+func (c *OrderedValue[T]) clone() *OrderedValue[T] {
+	return &OrderedValue[T]{core: core{key: c.key, conf: c.conf}, val: c.val}
+}
+
 // Customize customized data validation
 // @layout error message
 // @f check function
 func (c *OrderedValue[T]) Customize(messageId string, f func(T) bool) *OrderedValue[T] {
-	return c.validate(messageId, f(c.val), nil)
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
 }
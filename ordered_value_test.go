@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
@@ -100,3 +101,99 @@ func TestOrderedValue_Between(t *testing.T) {
 	assert.Nil(t, Ordered("age", 4).Between(3, 5).Err())
 	assert.Error(t, Ordered("age", 5).Between(3, 5).Err())
 }
+
+func TestOrderedValue_All(t *testing.T) {
+	var err = Ordered("age", 3).All().Gte(18).In(20, 30).Err()
+	assert.Equal(t, "age must be greater than or equal to 18; age must be included in [20 30]", err.Error())
+	assert.Equal(t, "age must be greater than or equal to 18", Ordered("age", 3).Gte(18).In(20, 30).Err().Error())
+}
+
+func TestOrderedValue_When(t *testing.T) {
+	assert.Nil(t, Ordered("age", 0).When(false).Required().Gte(18).Err())
+	assert.Error(t, Ordered("age", 0).When(true).Required().Err())
+	assert.Error(t, Ordered("age", 0).RequiredIf("adult", true, true).Err())
+	assert.Nil(t, Ordered("age", 0).RequiredIf("adult", false, true).Err())
+	assert.Error(t, Ordered("age", 0).RequiredWith("birthday", []int{1}).Err())
+	assert.Nil(t, Ordered("age", 0).RequiredWith("birthday", []int{}).Err())
+	assert.Error(t, Ordered("age", 0).RequiredWithout("birthday", (*int)(nil)).Err())
+	assert.Error(t, Ordered("age", 1).ExcludedWith("birthday", map[string]int{"a": 1}).Err())
+}
+
+func TestOrderedValue_Optional(t *testing.T) {
+	assert.Nil(t, Ordered("age", 0).Optional().Gte(18).Err())
+	assert.Error(t, Ordered("age", 3).Optional().Gte(18).Err())
+	assert.Error(t, Ordered("age", 0).Lt(-1).Optional().Gte(18).Err())
+}
+
+func TestOrderedValue_Field(t *testing.T) {
+	assert.Nil(t, Ordered("max_price", 10).EqField("min_price", 10).GteField("min_price", 10).LteField("min_price", 10).Err())
+	assert.Nil(t, Ordered("max_price", 10).NeField("min_price", 9).GtField("min_price", 9).LtField("min_price", 11).Err())
+	assert.Equal(t, "End must be greater than Start", Ordered("End", 1).GtField("Start", 1).Err().Error())
+	assert.Error(t, Ordered("End", 1).EqField("Start", 2).Err())
+	assert.Error(t, Ordered("End", 1).NeField("Start", 1).Err())
+	assert.Error(t, Ordered("End", 1).GteField("Start", 2).Err())
+	assert.Error(t, Ordered("End", 1).LtField("Start", 1).Err())
+	assert.Error(t, Ordered("End", 1).LteField("Start", 0).Err())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(Ordered("max_price", 1.5).GteField("min_price", 2))
+		assert.Equal(t, "max_price 须大于或等于min_price", err.Error())
+		var e *FieldError
+		assert.True(t, errors.As(err, &e))
+		assert.Equal(t, []any{"min_price"}, e.Args)
+	})
+}
+
+func TestOrderedValue_Combinator(t *testing.T) {
+	var port = func(v int) *OrderedValue[int] {
+		return Ordered("port", v).AnyOf(
+			func(o *OrderedValue[int]) { o.In(80, 443) },
+			func(o *OrderedValue[int]) { o.Between(8000, 9000) },
+		)
+	}
+	assert.Nil(t, port(443).Err())
+	assert.Nil(t, port(8080).Err())
+	assert.Error(t, port(22).Err())
+	assert.Nil(t, Ordered("age", 20).AllOf(func(o *OrderedValue[int]) { o.Gte(18).Lt(60) }).Err())
+	assert.Error(t, Ordered("age", 60).AllOf(func(o *OrderedValue[int]) { o.Gte(18).Lt(60) }).Err())
+	assert.Nil(t, Ordered("age", 17).Not(func(o *OrderedValue[int]) { o.Gte(18) }).Err())
+	assert.Equal(t, "age must not satisfy (age must be greater than or equal to 18)", Ordered("age", 18).Not(func(o *OrderedValue[int]) { o.Gte(18) }).Err().Error())
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, Ordered("age", 1).AnyOf().Err())
+		assert.Nil(t, Ordered("age", 1).AllOf(func(o *OrderedValue[int]) { o.Unless(true).Gte(18) }).Err())
+		assert.Nil(t, Ordered("age", 1).Not(func(o *OrderedValue[int]) {}).Err())
+		assert.Nil(t, Ordered("age", 1).Not(func(o *OrderedValue[int]) { o.When(false).Gte(18) }).Err())
+	})
+}
+
+func TestOrderedValue_Transform(t *testing.T) {
+	assert.Equal(t, 100, Ordered("size", 1000).Clamp(1, 100).Value())
+	assert.Equal(t, 1, Ordered("size", -5).Clamp(1, 100).Value())
+	assert.Equal(t, 20, Ordered("size", 20).Clamp(1, 100).Value())
+	assert.Equal(t, 0.5, Ordered("ratio", 50.0).Transform(func(v float64) float64 { return v / 100 }).Lte(1).Value())
+	assert.Nil(t, Ordered("size", 0).Clamp(1, 100).Required().Err())
+
+	t.Run("skip", func(t *testing.T) {
+		var size = 1000
+		assert.Equal(t, 1000, OrderedRef("size", &size).When(false).Clamp(1, 100).Value())
+		assert.Equal(t, 1000, size)
+		assert.Equal(t, 100, OrderedRef("size", &size).When(true).Clamp(1, 100).Value())
+		assert.Equal(t, 100, size)
+	})
+}
+
+func TestOrderedValue_Default(t *testing.T) {
+	var req = struct{ PageSize, Page int }{Page: 3}
+	assert.Nil(t, OrderedRef("PageSize", &req.PageSize).Default(20).Between(1, 101).Err())
+	assert.Nil(t, OrderedRef("Page", &req.Page).Default(1).Err())
+	assert.Equal(t, 20, req.PageSize)
+	assert.Equal(t, 3, req.Page)
+
+	assert.Equal(t, 5, Ordered("size", 0).Default(5).Value())
+	assert.Error(t, Ordered("size", 0).Default(200).Lte(100).Err())
+
+	var size = 1000
+	OrderedRef("size", &size).Clamp(1, 100)
+	assert.Equal(t, 100, size)
+}
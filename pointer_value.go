@@ -1,46 +1,99 @@
 package validator
 
 import (
-	"errors"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
-	"strconv"
+	"cmp"
+	"golang.org/x/text/language"
 )
 
 type PointerValue[T any] struct {
-	err     error
-	key     string
-	val     *T
-	mark    bool
-	conf    *config
-	locConf *i18n.LocalizeConfig
+	err error
+	core
+	val *T
 }
 
 func Pointer[T any](k string, v *T) *PointerValue[T] {
 	return &PointerValue[T]{
-		key:  k,
+		core: newCore(k),
 		val:  v,
-		conf: _conf,
 	}
 }
 
-func (c *PointerValue[T]) setConf(conf *config) {
-	c.conf = conf
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *PointerValue[T]) All() *PointerValue[T] {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *PointerValue[T]) Label(id string) *PointerValue[T] {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *PointerValue[T]) Msg(text string) *PointerValue[T] {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *PointerValue[T]) Msgs(texts map[language.Tag]string) *PointerValue[T] {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *PointerValue[T]) MsgID(id string) *PointerValue[T] {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *PointerValue[T]) When(cond bool) *PointerValue[T] {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *PointerValue[T]) Unless(cond bool) *PointerValue[T] {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *PointerValue[T]) Optional() *PointerValue[T] {
+	c.setOptional(c.val == nil)
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *PointerValue[T]) RequiredIf(other string, otherVal, want any) *PointerValue[T] {
+	c.requiredIf(c.val != nil, other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *PointerValue[T]) RequiredWith(other string, otherVal any) *PointerValue[T] {
+	c.requiredWith(c.val != nil, other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *PointerValue[T]) RequiredWithout(other string, otherVal any) *PointerValue[T] {
+	c.requiredWithout(c.val != nil, other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *PointerValue[T]) ExcludedWith(other string, otherVal any) *PointerValue[T] {
+	c.excludedWith(c.val != nil, other, otherVal)
+	return c
 }
 
 func (c *PointerValue[T]) validate(messageId string, ok bool, args ...any) *PointerValue[T] {
-	if c.mark || ok {
-		return c
-	}
-	c.mark = true
-	td := map[string]any{"Key": c.key}
-	for i, v := range args {
-		key := "Arg" + strconv.Itoa(i)
-		td[key] = v
-	}
-	c.locConf = &i18n.LocalizeConfig{
-		MessageID:    messageId,
-		TemplateData: td,
-	}
+	c.check(messageId, ok, args...)
 	return c
 }
 
@@ -51,20 +104,40 @@ func (c *PointerValue[T]) Err() error {
 	if c.err != nil {
 		return c.err
 	}
-	str, err := c.conf.loc.Localize(c.locConf)
-	if err != nil {
-		c.err = err
-		return c.err
-	}
-
-	c.err = errors.New(str)
+	c.err = c.fieldError(c.val)
 	return c.err
 }
 
 func (c *PointerValue[T]) Required() *PointerValue[T] {
-	return c.validate("PointerValue.Required", c.val != nil, nil)
+	return c.validate("PointerValue.Required", c.val != nil)
 }
 
 func (c *PointerValue[T]) Customize(messageId string, f func(*T) bool) *PointerValue[T] {
-	return c.validate(messageId, f(c.val), nil)
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
+}
+
+// PointerString validates the string pointed to by v with the StringValue rules under the same key.
+// The rules are skipped when v is nil, even after Optional or Default, use Pointer(k, v).Required() if the field must be present.
+func PointerString[T ~string](k string, v *T) *StringValue[T] {
+	if v == nil {
+		var c = String(k, T(""))
+		c.absent = true
+		return c
+	}
+	return String(k, *v)
+}
+
+// PointerOrdered validates the number pointed to by v with the OrderedValue rules under the same key.
+// The rules are skipped when v is nil, even after Optional or Default, use Pointer(k, v).Required() if the field must be present.
+func PointerOrdered[T cmp.Ordered](k string, v *T) *OrderedValue[T] {
+	if v == nil {
+		var zero T
+		var c = Ordered(k, zero)
+		c.absent = true
+		return c
+	}
+	return Ordered(k, *v)
 }
@@ -63,3 +63,37 @@ func TestPointerValue_Customize(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestPointerValue_Optional(t *testing.T) {
+	var f = func(v *int) bool { return *v > 0 }
+	assert.Nil(t, Pointer[int]("id", nil).Optional().Customize("PointerValue.Customize", f).Err())
+	assert.Error(t, Pointer("id", new(int)).Optional().Customize("PointerValue.Customize", f).Err())
+}
+
+func TestPointerString(t *testing.T) {
+	var name = "aha"
+	assert.Nil(t, PointerString[string]("name", nil).Required().Gte(5).Err())
+	assert.Nil(t, PointerString[string]("name", nil).Optional().Email().Err())
+	assert.Nil(t, PointerString[string]("name", nil).Optional().Default("d").Gte(3).Err())
+	assert.Nil(t, PointerString[string]("name", nil).Default("d").Optional().Gte(3).Err())
+	assert.Nil(t, PointerString("name", &name).Required().Lte(3).Err())
+	assert.Equal(t, "name length must be greater than or equal to 5", PointerString("name", &name).Gte(5).Err().Error())
+
+	t.Run("", func(t *testing.T) {
+		var err = Nested("user", PointerString("name", &name).Gte(5)).Err()
+		assert.Equal(t, "user.name", err.(*FieldError).Key)
+		assert.Equal(t, "aha", err.(*FieldError).Value)
+	})
+}
+
+func TestPointerOrdered(t *testing.T) {
+	var age = 12
+	assert.Nil(t, PointerOrdered[int]("age", nil).Required().Gte(18).Err())
+	assert.Nil(t, PointerOrdered[int]("age", nil).Optional().Default(1).Gte(18).Err())
+	assert.Nil(t, PointerOrdered("age", &age).Between(0, 18).Err())
+	assert.Equal(t, "age must be greater than or equal to 18", PointerOrdered("age", &age).Gte(18).Err().Error())
+	assert.Error(t, NewValidator(newReq("en-US")).Validate(
+		Pointer[int]("age", nil).Required(),
+		PointerOrdered[int]("age", nil).Gte(18),
+	))
+}
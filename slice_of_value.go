@@ -0,0 +1,228 @@
+package validator
+
+import (
+	"golang.org/x/text/language"
+	"reflect"
+	"strconv"
+)
+
+// SliceOfValue validates a slice of any element type, such as []bool, []*T or [][]byte.
+// Elements are compared with == when the element type is comparable, otherwise with reflect.DeepEqual.
+type SliceOfValue[T any] struct {
+	err error
+	core
+	val    []T
+	values []Valuer
+}
+
+func SliceOf[T any](k string, v []T) *SliceOfValue[T] {
+	return &SliceOfValue[T]{
+		core: newCore(k),
+		val:  v,
+	}
+}
+
+func (c *SliceOfValue[T]) setPrefix(prefix string) {
+	c.key = joinKey(prefix, c.key)
+	for _, item := range c.values {
+		item.setPrefix(prefix)
+	}
+}
+
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *SliceOfValue[T]) All() *SliceOfValue[T] {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *SliceOfValue[T]) Label(id string) *SliceOfValue[T] {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *SliceOfValue[T]) Msg(text string) *SliceOfValue[T] {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *SliceOfValue[T]) Msgs(texts map[language.Tag]string) *SliceOfValue[T] {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *SliceOfValue[T]) MsgID(id string) *SliceOfValue[T] {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *SliceOfValue[T]) When(cond bool) *SliceOfValue[T] {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *SliceOfValue[T]) Unless(cond bool) *SliceOfValue[T] {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *SliceOfValue[T]) Optional() *SliceOfValue[T] {
+	c.setOptional(len(c.val) == 0)
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *SliceOfValue[T]) RequiredIf(other string, otherVal, want any) *SliceOfValue[T] {
+	c.requiredIf(len(c.val) > 0, other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *SliceOfValue[T]) RequiredWith(other string, otherVal any) *SliceOfValue[T] {
+	c.requiredWith(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *SliceOfValue[T]) RequiredWithout(other string, otherVal any) *SliceOfValue[T] {
+	c.requiredWithout(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *SliceOfValue[T]) ExcludedWith(other string, otherVal any) *SliceOfValue[T] {
+	c.excludedWith(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+func (c *SliceOfValue[T]) validate(messageId string, ok bool, args ...any) *SliceOfValue[T] {
+	c.check(messageId, ok, args...)
+	return c
+}
+
+// Err get error
+func (c *SliceOfValue[T]) Err() error {
+	if c.err != nil {
+		return c.err
+	}
+	c.err = composeError(c.conf, c.key, c.label, c.val, c.locConfs, c.values, c.all || c.conf.all)
+	return c.err
+}
+
+// Required the slice cannot be empty
+func (c *SliceOfValue[T]) Required() *SliceOfValue[T] {
+	return c.validate("SliceValue.Required", len(c.val) > 0)
+}
+
+// Eq check the slice length is equal to v
+func (c *SliceOfValue[T]) Eq(v int) *SliceOfValue[T] {
+	return c.validate("SliceValue.Eq", len(c.val) == v, v)
+}
+
+// Gt check the slice length is greater than v
+func (c *SliceOfValue[T]) Gt(v int) *SliceOfValue[T] {
+	return c.validate("SliceValue.Gt", len(c.val) > v, v)
+}
+
+// Gte check the slice length is greater or equal than v
+func (c *SliceOfValue[T]) Gte(v int) *SliceOfValue[T] {
+	return c.validate("SliceValue.Gte", len(c.val) >= v, v)
+}
+
+// Lt check the slice length is less than v
+func (c *SliceOfValue[T]) Lt(v int) *SliceOfValue[T] {
+	return c.validate("SliceValue.Lt", len(c.val) < v, v)
+}
+
+// Lte check the slice length is less or equal than v
+func (c *SliceOfValue[T]) Lte(v int) *SliceOfValue[T] {
+	return c.validate("SliceValue.Lte", len(c.val) <= v, v)
+}
+
+// Contains checks whether the slice contains v
+func (c *SliceOfValue[T]) Contains(v T) *SliceOfValue[T] {
+	return c.validate("SliceValue.Contains", c.indexOf(v) >= 0, v)
+}
+
+// NotContains checks that the slice does not contain v
+func (c *SliceOfValue[T]) NotContains(v T) *SliceOfValue[T] {
+	return c.validate("SliceValue.NotContains", c.indexOf(v) < 0, v)
+}
+
+// Unique checks that the slice has no duplicate elements, the first duplicate is reported.
+func (c *SliceOfValue[T]) Unique() *SliceOfValue[T] {
+	var equal = equalFunc[T]()
+	for i := 1; i < len(c.val); i++ {
+		for j := 0; j < i; j++ {
+			if equal(c.val[i], c.val[j]) {
+				return c.validate("SliceValue.Unique", false, c.val[i])
+			}
+		}
+	}
+	return c.validate("SliceValue.Unique", true)
+}
+
+// Each validates every element with the Valuer returned by f, errors are reported as key[i].
+func (c *SliceOfValue[T]) Each(f func(elem T) Valuer) *SliceOfValue[T] {
+	if c.skipped() {
+		return c
+	}
+	for i, elem := range c.val {
+		var value = f(elem)
+		value.setPrefix(c.key + "[" + strconv.Itoa(i) + "]")
+		c.values = append(c.values, value)
+	}
+	return c
+}
+
+func (c *SliceOfValue[T]) Customize(messageId string, f func([]T) bool) *SliceOfValue[T] {
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
+}
+
+func (c *SliceOfValue[T]) indexOf(v T) int {
+	var equal = equalFunc[T]()
+	for i := range c.val {
+		if equal(c.val[i], v) {
+			return i
+		}
+	}
+	return -1
+}
+
+// equalFunc 可比较的类型使用==, 其余类型使用 reflect.DeepEqual
+func equalFunc[T any]() func(a, b T) bool {
+	if strictlyComparable(reflect.TypeOf((*T)(nil)).Elem()) {
+		return func(a, b T) bool { return any(a) == any(b) }
+	}
+	return func(a, b T) bool { return reflect.DeepEqual(a, b) }
+}
+
+// strictlyComparable 使用==比较不会panic, 接口可能存放不可比较的值
+func strictlyComparable(rt reflect.Type) bool {
+	switch rt.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Array:
+		return strictlyComparable(rt.Elem())
+	case reflect.Struct:
+		for i := 0; i < rt.NumField(); i++ {
+			if !strictlyComparable(rt.Field(i).Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return rt.Comparable()
+	}
+}
@@ -0,0 +1,82 @@
+package validator
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"testing"
+	"time"
+)
+
+func TestSliceOfValue_Required(t *testing.T) {
+	assert.Nil(t, SliceOf("flags", []bool{true}).Required().Err())
+	assert.Equal(t, "flags cannot be empty", SliceOf[bool]("flags", nil).Required().Err().Error())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(SliceOf[[]byte]("chunks", nil).Required())
+		assert.Equal(t, "chunks 不能为空", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var value = SliceOf[*int]("ids", nil).Required()
+		value.locConf.MessageID = "aha"
+		assert.Error(t, value.Err())
+		assert.Error(t, value.Err())
+	})
+}
+
+func TestSliceOfValue_Length(t *testing.T) {
+	var a = []time.Time{{}, {}}
+	assert.Nil(t, SliceOf("at", a).Eq(2).Gt(1).Gte(2).Lt(3).Lte(2).Err())
+	assert.Equal(t, "at length must equal 3", SliceOf("at", a).Eq(3).Err().Error())
+	assert.Error(t, SliceOf("at", a).Gt(2).Err())
+	assert.Error(t, SliceOf("at", a).Gte(3).Err())
+	assert.Error(t, SliceOf("at", a).Lt(2).Err())
+	assert.Error(t, SliceOf("at", a).Lte(1).Err())
+	assert.Equal(t, 2, len(SliceOf("at", a).All().Lt(1).Gt(2).Err().(Errors)))
+}
+
+func TestSliceOfValue_Elements(t *testing.T) {
+	type Item struct {
+		Name string
+		Tags []string
+	}
+
+	t.Run("comparable", func(t *testing.T) {
+		var a = []bool{true, false}
+		assert.Nil(t, SliceOf("flags", []bool{true}).Contains(true).NotContains(false).Err())
+		assert.Nil(t, SliceOf("flags", a).Contains(false).Unique().Err())
+		assert.Equal(t, "flags must not contain duplicate elements, true is repeated", SliceOf("flags", append(a, true)).Unique().Err().Error())
+		assert.Equal(t, "flags must contain true", SliceOf("flags", []bool{false}).Contains(true).Err().Error())
+		assert.Equal(t, "flags must not contain false", SliceOf("flags", a).NotContains(false).Err().Error())
+	})
+
+	t.Run("not comparable", func(t *testing.T) {
+		var a = []Item{{Name: "a", Tags: []string{"x"}}, {Name: "b"}}
+		assert.Nil(t, SliceOf("items", a).Contains(Item{Name: "a", Tags: []string{"x"}}).Unique().Err())
+		assert.Error(t, SliceOf("items", a).NotContains(Item{Name: "b"}).Err())
+		assert.Error(t, SliceOf("items", append(a, Item{Name: "b"})).Unique().Err())
+		assert.Error(t, SliceOf("chunks", [][]byte{{1}, {1}}).Unique().Err())
+		assert.Error(t, SliceOf("values", []any{[]int{1}, []int{1}}).Unique().Err())
+		assert.Error(t, SliceOf("pairs", []struct{ V any }{{V: []int{1}}, {V: []int{1}}}).Unique().Err())
+		assert.Nil(t, SliceOf("arrays", [][1]any{{[]int{1}}, {[]int{2}}}).Unique().Err())
+	})
+
+	t.Run("each", func(t *testing.T) {
+		var a = []Item{{Name: "a"}, {}}
+		var err = Nested("order", SliceOf("items", a).Each(func(item Item) Valuer {
+			return String("name", item.Name).Required()
+		})).Err()
+		assert.Equal(t, "order.items[1].name cannot be empty", err.Error())
+	})
+}
+
+func TestSliceOfValue_Customize(t *testing.T) {
+	_ = GetBundle().AddMessages(language.Make("en-US"), &i18n.Message{
+		ID:    "Customize",
+		Other: "未成年人禁止入内",
+	})
+	var f = func(a []bool) bool { return len(a) > 1 }
+	assert.Equal(t, "未成年人禁止入内", SliceOf("flags", []bool{true}).Customize("Customize", f).Err().Error())
+	assert.Nil(t, SliceOf("flags", []bool{true, true}).Customize("Customize", f).Err())
+}
@@ -2,69 +2,148 @@ package validator
 
 import (
 	"cmp"
-	"errors"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"golang.org/x/text/language"
 	"strconv"
 )
 
 type SliceValue[T cmp.Ordered] struct {
-	err     error
-	key     string
-	val     []T
-	mark    bool
-	conf    *config
-	locConf *i18n.LocalizeConfig
+	err error
+	core
+	val    []T
+	ref    *[]T
+	values []Valuer
 }
 
 func Slice[T cmp.Ordered](k string, v []T) *SliceValue[T] {
 	return &SliceValue[T]{
-		key:  k,
+		core: newCore(k),
 		val:  v,
-		conf: _conf,
 	}
 }
 
-func (c *SliceValue[T]) setConf(conf *config) {
-	c.conf = conf
+// SliceRef validates *v like Slice, Default writes the new value back to *v.
+func SliceRef[T cmp.Ordered](k string, v *[]T) *SliceValue[T] {
+	var c = Slice(k, *v)
+	c.ref = v
+	return c
 }
 
-func (c *SliceValue[T]) validate(messageId string, ok bool, args ...any) *SliceValue[T] {
-	if c.mark || ok {
-		return c
-	}
-	c.mark = true
-	td := map[string]any{"Key": c.key}
-	for i, v := range args {
-		key := "Arg" + strconv.Itoa(i)
-		td[key] = v
+// Default sets the slice to v when it is empty, it must be called before the rules.
+// It is skipped like the rules after When(false) or Unless(true).
+func (c *SliceValue[T]) Default(v []T) *SliceValue[T] {
+	if !c.skip && len(c.val) == 0 {
+		c.val = v
+		c.update(len(v) == 0)
+		if c.ref != nil {
+			*c.ref = v
+		}
 	}
-	c.locConf = &i18n.LocalizeConfig{
-		MessageID:    messageId,
-		TemplateData: td,
+	return c
+}
+
+// Value returns the slice checked by the rules.
+func (c *SliceValue[T]) Value() []T {
+	return c.val
+}
+
+func (c *SliceValue[T]) setPrefix(prefix string) {
+	c.key = joinKey(prefix, c.key)
+	for _, item := range c.values {
+		item.setPrefix(prefix)
 	}
+}
+
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *SliceValue[T]) All() *SliceValue[T] {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *SliceValue[T]) Label(id string) *SliceValue[T] {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *SliceValue[T]) Msg(text string) *SliceValue[T] {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *SliceValue[T]) Msgs(texts map[language.Tag]string) *SliceValue[T] {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *SliceValue[T]) MsgID(id string) *SliceValue[T] {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *SliceValue[T]) When(cond bool) *SliceValue[T] {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *SliceValue[T]) Unless(cond bool) *SliceValue[T] {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *SliceValue[T]) Optional() *SliceValue[T] {
+	c.setOptional(len(c.val) == 0)
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *SliceValue[T]) RequiredIf(other string, otherVal, want any) *SliceValue[T] {
+	c.requiredIf(len(c.val) > 0, other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *SliceValue[T]) RequiredWith(other string, otherVal any) *SliceValue[T] {
+	c.requiredWith(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *SliceValue[T]) RequiredWithout(other string, otherVal any) *SliceValue[T] {
+	c.requiredWithout(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *SliceValue[T]) ExcludedWith(other string, otherVal any) *SliceValue[T] {
+	c.excludedWith(len(c.val) > 0, other, otherVal)
+	return c
+}
+
+func (c *SliceValue[T]) validate(messageId string, ok bool, args ...any) *SliceValue[T] {
+	c.check(messageId, ok, args...)
 	return c
 }
 
 // Err get error
 func (c *SliceValue[T]) Err() error {
-	if !c.mark {
-		return nil
-	}
 	if c.err != nil {
 		return c.err
 	}
-	str, err := c.conf.loc.Localize(c.locConf)
-	if err != nil {
-		c.err = err
-		return c.err
-	}
-	c.err = errors.New(str)
+	c.err = composeError(c.conf, c.key, c.label, c.val, c.locConfs, c.values, c.all || c.conf.all)
 	return c.err
 }
 
 // Required the slice cannot be empty
 func (c *SliceValue[T]) Required() *SliceValue[T] {
-	return c.validate("SliceValue.Required", len(c.val) > 0, nil)
+	return c.validate("SliceValue.Required", len(c.val) > 0)
 }
 
 // Eq check the slice length is equal to v
@@ -97,6 +176,187 @@ func (c *SliceValue[T]) Contains(v T) *SliceValue[T] {
 	return c.validate("SliceValue.Contains", contains(c.val, v), v)
 }
 
+// NotContains checks that the slice does not contain v
+func (c *SliceValue[T]) NotContains(v T) *SliceValue[T] {
+	return c.validate("SliceValue.NotContains", !contains(c.val, v), v)
+}
+
+// ContainsAll checks whether the slice contains every element of args, the first missing one is reported.
+func (c *SliceValue[T]) ContainsAll(args ...T) *SliceValue[T] {
+	for _, item := range args {
+		if !contains(c.val, item) {
+			return c.validate("SliceValue.ContainsAll", false, item, args)
+		}
+	}
+	return c.validate("SliceValue.ContainsAllRule", true, args)
+}
+
+// ContainsAny checks whether the slice contains at least one element of args
+func (c *SliceValue[T]) ContainsAny(args ...T) *SliceValue[T] {
+	for _, item := range args {
+		if contains(c.val, item) {
+			return c.validate("SliceValue.ContainsAny", true, args)
+		}
+	}
+	return c.validate("SliceValue.ContainsAny", false, args)
+}
+
+// SubsetOf check if args contains every element of the slice, the first element not allowed is reported.
+func (c *SliceValue[T]) SubsetOf(args ...T) *SliceValue[T] {
+	for _, item := range c.val {
+		if !contains(args, item) {
+			return c.validate("SliceValue.SubsetOf", false, item, args)
+		}
+	}
+	return c.validate("SliceValue.SubsetOfRule", true, args)
+}
+
+// Unique checks that the slice has no duplicate elements, the first duplicate is reported.
+func (c *SliceValue[T]) Unique() *SliceValue[T] {
+	var set = make(map[T]struct{}, len(c.val))
+	for _, item := range c.val {
+		if _, ok := set[item]; ok {
+			return c.validate("SliceValue.Unique", false, item)
+		}
+		set[item] = struct{}{}
+	}
+	return c.validate("SliceValue.UniqueRule", true)
+}
+
+// Sorted checks that the slice is in ascending order, the first element out of order is reported.
+func (c *SliceValue[T]) Sorted() *SliceValue[T] {
+	for i := 1; i < len(c.val); i++ {
+		if c.val[i] < c.val[i-1] {
+			return c.validate("SliceValue.Sorted", false, c.val[i])
+		}
+	}
+	return c.validate("SliceValue.SortedRule", true)
+}
+
+// SortedDesc checks that the slice is in descending order, the first element out of order is reported.
+func (c *SliceValue[T]) SortedDesc() *SliceValue[T] {
+	for i := 1; i < len(c.val); i++ {
+		if c.val[i] > c.val[i-1] {
+			return c.validate("SliceValue.SortedDesc", false, c.val[i])
+		}
+	}
+	return c.validate("SliceValue.SortedDescRule", true)
+}
+
+// Each validates every element with the Valuer returned by f, errors are reported as key[i].
+func (c *SliceValue[T]) Each(f func(elem T) Valuer) *SliceValue[T] {
+	if c.skipped() {
+		return c
+	}
+	for i, elem := range c.val {
+		var value = f(elem)
+		value.setPrefix(c.key + "[" + strconv.Itoa(i) + "]")
+		c.values = append(c.values, value)
+	}
+	return c
+}
+
+// AnyOf check that the slice passes at least one of the rule sets, such as IPv4 or IPv6.
+// The message lists the first broken rule of every set, empty sets and sets whose rules are all skipped are ignored.
+func (c *SliceValue[T]) AnyOf(fs ...func(s *SliceValue[T])) *SliceValue[T] {
+	if c.done() {
+		return c.validate("SliceValue.AnyOf", true)
+	}
+	var rules subRules
+	for _, f := range fs {
+		var v = c.clone()
+		f(v)
+		if v.invalid != nil {
+			c.fail(v.invalid)
+			return c
+		}
+		if !v.ran {
+			continue
+		}
+		if !v.mark {
+			return c.validate("SliceValue.AnyOf", true, c.passed(f))
+		}
+		rules = append(rules, v.locConf)
+	}
+	if len(rules) == 0 {
+		c.ignore()
+		return c
+	}
+	return c.validate("SliceValue.AnyOf", false, rules)
+}
+
+// AllOf check that the slice passes all the rule sets, the message lists every broken rule.
+// It is ignored when the sets are empty or all their rules are skipped.
+func (c *SliceValue[T]) AllOf(fs ...func(s *SliceValue[T])) *SliceValue[T] {
+	if c.done() {
+		return c.validate("SliceValue.AllOf", true)
+	}
+	var v = c.clone()
+	v.all = true
+	for _, f := range fs {
+		f(v)
+	}
+	if v.invalid != nil {
+		c.fail(v.invalid)
+		return c
+	}
+	if !v.ran {
+		c.ignore()
+		return c
+	}
+	if !v.mark {
+		return c.validate("SliceValue.AllOf", true, c.passed(fs...))
+	}
+	return c.validate("SliceValue.AllOf", false, subRules(v.locConfs))
+}
+
+// Not check that the slice breaks the rule set, the message lists the rules that passed.
+// A rule that cannot be checked, such as an invalid regular expression, breaks Not as well.
+// It is ignored when the set is empty or all its rules are skipped.
+func (c *SliceValue[T]) Not(f func(s *SliceValue[T])) *SliceValue[T] {
+	if c.done() {
+		return c.validate("SliceValue.Not", true)
+	}
+	var v = c.clone()
+	f(v)
+	if v.invalid != nil {
+		c.fail(v.invalid)
+		return c
+	}
+	if !v.ran {
+		c.ignore()
+		return c
+	}
+	if v.mark {
+		return c.validate("SliceValue.Not", true, subRules(v.locConfs))
+	}
+	v = c.clone()
+	v.invert = true
+	return c.validate("SliceValue.Not", false, v.passed(f))
+}
+
+// passed 反转校验结果, 收集通过的规则, 用于 Not 的信息; 不在 Not 中时不需要
+func (c *SliceValue[T]) passed(fs ...func(s *SliceValue[T])) subRules {
+	if !c.invert {
+		return nil
+	}
+	var v = c.clone()
+	v.all = true
+	v.invert = true
+	for _, f := range fs {
+		f(v)
+	}
+	return subRules(v.locConfs)
+}
+
+// clone 复制键和值, 用于组合规则, 规则集中的 Each 不生效
+func (c *SliceValue[T]) clone() *SliceValue[T] {
+	return &SliceValue[T]{core: core{key: c.key, conf: c.conf}, val: c.val}
+}
+
 func (c *SliceValue[T]) Customize(messageId string, f func([]T) bool) *SliceValue[T] {
-	return c.validate(messageId, f(c.val), nil)
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
 }
@@ -119,3 +119,122 @@ func TestSliceValue_Customize(t *testing.T) {
 		assert.Nil(t, err)
 	})
 }
+
+func TestSliceValue_All(t *testing.T) {
+	var err = Slice("roles", []int{1}).All().Gte(2).Contains(3).Err()
+	assert.Equal(t, 2, len(err.(Errors)))
+}
+
+func TestSliceValue_Each(t *testing.T) {
+	var tags = []string{"a@qq.com", "b@qq.com", "c", "d"}
+
+	t.Run("", func(t *testing.T) {
+		var err = Slice("Tags", tags).Each(func(s string) Valuer { return String("", s).Email() }).Err()
+		assert.Equal(t, "Tags[2] must be in email address format", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).ValidateAll(
+			Nested("user", Slice("Tags", tags).Lte(3).Each(func(s string) Valuer { return String("", s).Email() })),
+		)
+		var list = err.(Errors)
+		assert.Equal(t, 3, len(list))
+		assert.Equal(t, "user.Tags 长度须小于等于3", list[0].Error())
+		assert.Equal(t, "user.Tags[2] 须符合电子邮件地址格式", list[1].Error())
+		assert.Equal(t, "user.Tags[3] 须符合电子邮件地址格式", list[2].Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var ids = []int{1, 2, 0}
+		assert.Equal(t, "IDs[2] must be greater than 0", Slice("IDs", ids).Each(func(id int) Valuer {
+			return Ordered("", id).Gt(0)
+		}).Err().Error())
+		assert.Equal(t, "IDs length must be greater than 3", Slice("IDs", ids).Gt(3).Each(func(id int) Valuer {
+			return Ordered("", id).Gt(0)
+		}).Err().Error())
+		assert.Nil(t, Slice("IDs", ids).Each(func(id int) Valuer { return Ordered("", id).Gte(0) }).Err())
+	})
+}
+
+func TestSliceValue_Elements(t *testing.T) {
+	var a = []int{1, 3, 5, 3}
+	assert.Nil(t, Slice("ids", a).NotContains(2).Err())
+	assert.Equal(t, "ids must not contain 3", Slice("ids", a).NotContains(3).Err().Error())
+	assert.Nil(t, Slice("ids", a).ContainsAll(1, 5).Err())
+	assert.Equal(t, "ids must contain all of [1 2 5], 2 is missing", Slice("ids", a).ContainsAll(1, 2, 5).Err().Error())
+	assert.Nil(t, Slice("ids", a).ContainsAny(2, 5).Err())
+	assert.Equal(t, "ids must contain at least one of [2 4]", Slice("ids", a).ContainsAny(2, 4).Err().Error())
+	assert.Nil(t, Slice("ids", a).SubsetOf(1, 3, 5).Err())
+	assert.Equal(t, "ids element 5 must be included in [1 3]", Slice("ids", a).SubsetOf(1, 3).Err().Error())
+	assert.Nil(t, Slice("ids", []int{1, 2}).Unique().Err())
+	assert.Equal(t, "ids must not contain duplicate elements, 3 is repeated", Slice("ids", a).Unique().Err().Error())
+	assert.Nil(t, Slice("ids", []int{1, 1, 2}).Sorted().Err())
+	assert.Equal(t, "ids must be in ascending order, 3 is out of order", Slice("ids", a).Sorted().Err().Error())
+	assert.Nil(t, Slice("ids", []int{2, 2, 1}).SortedDesc().Err())
+	assert.Equal(t, "ids must be in descending order, 3 is out of order", Slice("ids", a).SortedDesc().Err().Error())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(Slice("ids", a).Unique())
+		assert.Equal(t, "ids 不能包含重复元素, 3重复", err.Error())
+	})
+}
+
+func TestSliceValue_When(t *testing.T) {
+	var each = func(s string) Valuer { return String("", s).Required() }
+	assert.Nil(t, Slice("tags", []string{""}).When(false).Gt(1).Each(each).Err())
+	assert.Error(t, Slice("tags", []string{""}).When(true).Each(each).Err())
+	assert.Error(t, Slice[string]("tags", nil).RequiredWith("name", "aha").Err())
+	assert.Nil(t, SliceOf("flags", []bool{true}).Unless(true).Eq(2).Each(func(b bool) Valuer { return Any("", b).Customize("Customize", func(bool) bool { return false }) }).Err())
+	assert.Error(t, SliceOf[bool]("flags", nil).RequiredIf("type", 1, 1).Err())
+}
+
+func TestSliceValue_Optional(t *testing.T) {
+	assert.Nil(t, Slice[string]("tags", nil).Optional().Gte(2).Err())
+	assert.Nil(t, Slice("tags", []string{}).Optional().Contains("a").Err())
+	assert.Error(t, Slice("tags", []string{"b"}).Optional().Contains("a").Err())
+	assert.Nil(t, SliceOf[bool]("flags", nil).Optional().Eq(1).Err())
+	assert.Nil(t, Map[string, int]("scores", nil).Optional().HasKey("a").Err())
+}
+
+func TestSliceValue_Combinator(t *testing.T) {
+	var f = func(s *SliceValue[int]) { s.Sorted() }
+	var g = func(s *SliceValue[int]) { s.SortedDesc() }
+	assert.Nil(t, Slice("ids", []int{1, 2}).AnyOf(f, g).Err())
+	assert.Nil(t, Slice("ids", []int{2, 1}).AnyOf(f, g).Err())
+	assert.Error(t, Slice("ids", []int{2, 1, 3}).AnyOf(f, g).Err())
+	assert.Error(t, Slice("ids", []int{2, 1}).AllOf(f, g).Err())
+	assert.Nil(t, Slice("ids", []int{1, 1}).Not(func(s *SliceValue[int]) { s.Unique() }).Err())
+	assert.Error(t, Slice("ids", []int{1, 2}).Not(func(s *SliceValue[int]) { s.Unique() }).Err())
+
+	t.Run("not", func(t *testing.T) {
+		var not = func(f func(s *SliceValue[int])) string {
+			return Slice("ids", []int{1, 2, 3}).Not(f).Err().Error()
+		}
+		assert.Equal(t, "ids must not satisfy (ids must not contain duplicate elements)", not(func(s *SliceValue[int]) { s.Unique() }))
+		assert.Equal(t, "ids must not satisfy (ids must be in ascending order)", not(f))
+		assert.Equal(t, "ids must not satisfy (ids must be in descending order)", Slice("ids", []int{3, 2}).Not(g).Err().Error())
+		assert.Equal(t, "ids must not satisfy (ids elements must be included in [1 2 3 4])", not(func(s *SliceValue[int]) { s.SubsetOf(1, 2, 3, 4) }))
+		assert.Equal(t, "ids must not satisfy (ids must contain all of [1 2])", not(func(s *SliceValue[int]) { s.ContainsAll(1, 2) }))
+		assert.Equal(t, "ids must not satisfy (ids must contain at least one of [2 5])", not(func(s *SliceValue[int]) { s.ContainsAny(2, 5) }))
+		assert.Equal(t, "ids must not satisfy (ids must satisfy all of (ids must be in ascending order; ids must not contain duplicate elements))", not(func(s *SliceValue[int]) {
+			s.AllOf(f, func(s *SliceValue[int]) { s.Unique() })
+		}))
+		assert.NotContains(t, not(func(s *SliceValue[int]) { s.Not(g) }), "<no value>")
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		assert.Nil(t, Slice("ids", []int{2, 1}).AnyOf().Err())
+		assert.Nil(t, Slice("ids", []int{2, 1}).AnyOf(func(s *SliceValue[int]) { s.When(false).Sorted() }).Err())
+		assert.Nil(t, Slice("ids", []int{2, 1}).AllOf().Err())
+		assert.Nil(t, Slice("ids", []int{2, 1}).Not(func(s *SliceValue[int]) {}).Err())
+		assert.Nil(t, Slice("ids", []int{2, 1}).Not(func(s *SliceValue[int]) { s.When(false).Sorted() }).Err())
+	})
+}
+
+func TestSliceValue_Default(t *testing.T) {
+	var fields []string
+	assert.Nil(t, SliceRef("fields", &fields).Default([]string{"id", "name"}).SubsetOf("id", "name", "age").Err())
+	assert.Equal(t, []string{"id", "name"}, fields)
+	assert.Equal(t, []int{1}, Slice("ids", []int{1}).Default([]int{2}).Value())
+	assert.Error(t, Slice[int]("ids", nil).Default([]int{2, 2}).Unique().Err())
+}
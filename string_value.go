@@ -3,11 +3,13 @@ package validator
 import (
 	"encoding/base64"
 	"encoding/hex"
-	"errors"
-	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/rivo/uniseg"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
 	"regexp"
-	"strconv"
 	"strings"
+	"unicode"
+	"unicode/utf8"
 )
 
 var (
@@ -17,40 +19,109 @@ var (
 )
 
 type StringValue[T ~string] struct {
-	err     error
-	key     string
-	val     T
-	mark    bool
-	conf    *config
-	locConf *i18n.LocalizeConfig
+	err error
+	core
+	val  T
+	raw  T
+	ref  *T
+	trim bool
 }
 
+// String validates the string after strings.TrimSpace, use StringRaw to validate the exact bytes.
 func String[T ~string](k string, v T) *StringValue[T] {
 	return &StringValue[T]{
-		key:  k,
+		core: newCore(k),
 		val:  T(strings.TrimSpace(string(v))),
-		conf: _conf,
+		raw:  v,
+		trim: true,
 	}
 }
 
-func (c *StringValue[T]) setConf(conf *config) {
-	c.conf = conf
+// StringRaw validates the string as it is, without strings.TrimSpace.
+func StringRaw[T ~string](k string, v T) *StringValue[T] {
+	return &StringValue[T]{
+		core: newCore(k),
+		val:  v,
+		raw:  v,
+	}
+}
+
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *StringValue[T]) All() *StringValue[T] {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *StringValue[T]) Label(id string) *StringValue[T] {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *StringValue[T]) Msg(text string) *StringValue[T] {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *StringValue[T]) Msgs(texts map[language.Tag]string) *StringValue[T] {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *StringValue[T]) MsgID(id string) *StringValue[T] {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *StringValue[T]) When(cond bool) *StringValue[T] {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *StringValue[T]) Unless(cond bool) *StringValue[T] {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *StringValue[T]) Optional() *StringValue[T] {
+	c.setOptional(isZero(c.val))
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *StringValue[T]) RequiredIf(other string, otherVal, want any) *StringValue[T] {
+	c.requiredIf(!isZero(c.val), other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *StringValue[T]) RequiredWith(other string, otherVal any) *StringValue[T] {
+	c.requiredWith(!isZero(c.val), other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *StringValue[T]) RequiredWithout(other string, otherVal any) *StringValue[T] {
+	c.requiredWithout(!isZero(c.val), other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *StringValue[T]) ExcludedWith(other string, otherVal any) *StringValue[T] {
+	c.excludedWith(!isZero(c.val), other, otherVal)
+	return c
 }
 
 func (c *StringValue[T]) validate(messageId string, ok bool, args ...any) *StringValue[T] {
-	if c.mark || ok {
-		return c
-	}
-	c.mark = true
-	td := map[string]any{"Key": c.key}
-	for i, v := range args {
-		key := "Arg" + strconv.Itoa(i)
-		td[key] = v
-	}
-	c.locConf = &i18n.LocalizeConfig{
-		MessageID:    messageId,
-		TemplateData: td,
-	}
+	c.check(messageId, ok, args...)
 	return c
 }
 
@@ -62,18 +133,91 @@ func (c *StringValue[T]) Err() error {
 	if c.err != nil {
 		return c.err
 	}
-	str, err := c.conf.loc.Localize(c.locConf)
-	if err != nil {
-		c.err = err
-		return c.err
-	}
-	c.err = errors.New(str)
+	c.err = c.fieldError(c.val)
 	return c.err
 }
 
+// StringRef validates *v like String, the trimmed value, Default and the transforms are written back to *v.
+func StringRef[T ~string](k string, v *T) *StringValue[T] {
+	var c = String(k, *v)
+	c.ref = v
+	c.set(c.val)
+	return c
+}
+
+// Default sets the string to v when it is empty, it must be called before the rules.
+// It is skipped like the rules after When(false) or Unless(true).
+func (c *StringValue[T]) Default(v T) *StringValue[T] {
+	if !c.skip && isZero(c.val) {
+		c.raw = v
+		c.set(c.normalize(v))
+	}
+	return c
+}
+
+// set 更新值, 并写回引用, 重新判断可选字段是否为空
+func (c *StringValue[T]) set(v T) {
+	c.val = v
+	c.update(isZero(v))
+	if c.ref != nil {
+		*c.ref = v
+	}
+}
+
+// Value returns the string checked by the rules, after trimming and transforms.
+func (c *StringValue[T]) Value() T {
+	return c.val
+}
+
+// Transform replaces the string with f(string), the following rules check the new value.
+// It is skipped like the rules after When(false) or Unless(true).
+func (c *StringValue[T]) Transform(f func(T) T) *StringValue[T] {
+	if !c.skip {
+		c.set(f(c.val))
+	}
+	return c
+}
+
+// ToLower converts the string to lower case, such as an email address
+func (c *StringValue[T]) ToLower() *StringValue[T] {
+	return c.Transform(func(v T) T { return T(strings.ToLower(string(v))) })
+}
+
+// ToUpper converts the string to upper case
+func (c *StringValue[T]) ToUpper() *StringValue[T] {
+	return c.Transform(func(v T) T { return T(strings.ToUpper(string(v))) })
+}
+
+// NFC converts the string to Unicode normalization form C
+func (c *StringValue[T]) NFC() *StringValue[T] {
+	return c.Transform(func(v T) T { return T(norm.NFC.String(string(v))) })
+}
+
+// NFKC converts the string to Unicode normalization form KC, full-width letters become half-width
+func (c *StringValue[T]) NFKC() *StringValue[T] {
+	return c.Transform(func(v T) T { return T(norm.NFKC.String(string(v))) })
+}
+
+// CollapseSpace replaces every run of white space with a single space and trims the string
+func (c *StringValue[T]) CollapseSpace() *StringValue[T] {
+	return c.Transform(func(v T) T { return T(strings.Join(strings.Fields(string(v)), " ")) })
+}
+
+// StripControl removes the control characters, such as \x00 and \r
+func (c *StringValue[T]) StripControl() *StringValue[T] {
+	return c.Transform(func(v T) T {
+		return T(strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) {
+				return -1
+			}
+			return r
+		}, string(v)))
+	})
+}
+
 // Required the string cannot be empty
 func (c *StringValue[T]) Required() *StringValue[T] {
-	return c.validate("StringValue.Required", !isZero(c.val), nil)
+	return c.validate("StringValue.Required", !isZero(c.val))
 }
 
 // Eq check that the string length is equal to v
@@ -88,7 +232,7 @@ func (c *StringValue[T]) Gt(v int) *StringValue[T] {
 
 // Gte check that the string length is greater or equal than v
 func (c *StringValue[T]) Gte(v int) *StringValue[T] {
-	return c.validate("StringValue.gte", len(c.val) >= v, v)
+	return c.validate("StringValue.Gte", len(c.val) >= v, v)
 }
 
 // Lt check that the string length is less than v
@@ -101,6 +245,56 @@ func (c *StringValue[T]) Lte(v int) *StringValue[T] {
 	return c.validate("StringValue.Lte", len(c.val) <= v, v)
 }
 
+// RuneLenEq check that the number of runes (Unicode code points) is equal to v
+func (c *StringValue[T]) RuneLenEq(v int) *StringValue[T] {
+	return c.validate("StringValue.RuneLenEq", utf8.RuneCountInString(string(c.val)) == v, v)
+}
+
+// RuneLenGt check that the number of runes is greater than v
+func (c *StringValue[T]) RuneLenGt(v int) *StringValue[T] {
+	return c.validate("StringValue.RuneLenGt", utf8.RuneCountInString(string(c.val)) > v, v)
+}
+
+// RuneLenGte check that the number of runes is greater or equal than v
+func (c *StringValue[T]) RuneLenGte(v int) *StringValue[T] {
+	return c.validate("StringValue.RuneLenGte", utf8.RuneCountInString(string(c.val)) >= v, v)
+}
+
+// RuneLenLt check that the number of runes is less than v
+func (c *StringValue[T]) RuneLenLt(v int) *StringValue[T] {
+	return c.validate("StringValue.RuneLenLt", utf8.RuneCountInString(string(c.val)) < v, v)
+}
+
+// RuneLenLte check that the number of runes is less or equal than v
+func (c *StringValue[T]) RuneLenLte(v int) *StringValue[T] {
+	return c.validate("StringValue.RuneLenLte", utf8.RuneCountInString(string(c.val)) <= v, v)
+}
+
+// CharLenEq check that the number of user-perceived characters (grapheme clusters) is equal to v
+func (c *StringValue[T]) CharLenEq(v int) *StringValue[T] {
+	return c.validate("StringValue.CharLenEq", uniseg.GraphemeClusterCount(string(c.val)) == v, v)
+}
+
+// CharLenGt check that the number of user-perceived characters is greater than v
+func (c *StringValue[T]) CharLenGt(v int) *StringValue[T] {
+	return c.validate("StringValue.CharLenGt", uniseg.GraphemeClusterCount(string(c.val)) > v, v)
+}
+
+// CharLenGte check that the number of user-perceived characters is greater or equal than v
+func (c *StringValue[T]) CharLenGte(v int) *StringValue[T] {
+	return c.validate("StringValue.CharLenGte", uniseg.GraphemeClusterCount(string(c.val)) >= v, v)
+}
+
+// CharLenLt check that the number of user-perceived characters is less than v
+func (c *StringValue[T]) CharLenLt(v int) *StringValue[T] {
+	return c.validate("StringValue.CharLenLt", uniseg.GraphemeClusterCount(string(c.val)) < v, v)
+}
+
+// CharLenLte check that the number of user-perceived characters is less or equal than v
+func (c *StringValue[T]) CharLenLte(v int) *StringValue[T] {
+	return c.validate("StringValue.CharLenLte", uniseg.GraphemeClusterCount(string(c.val)) <= v, v)
+}
+
 // In check if args contains the string.
 func (c *StringValue[T]) In(args ...T) *StringValue[T] {
 	return c.validate("StringValue.In", contains(args, c.val), args)
@@ -113,81 +307,208 @@ func (c *StringValue[T]) Between(a, b T) *StringValue[T] {
 
 // MatchString verify that the string matches the regular expression re
 func (c *StringValue[T]) MatchString(re string) *StringValue[T] {
-	if c.mark {
-		return c
+	if c.done() {
+		return c.validate("StringValue.MatchString", true)
 	}
 	r, err := regexp.Compile(re)
 	if err != nil {
-		return c.validate("StringValue.ParseRegexp", false, nil)
+		c.fail(newLocalizeConfig("StringValue.ParseRegexp", nil))
+		return c
 	}
-	return c.validate("StringValue.MatchString", r.MatchString(string(c.val)), nil)
+	return c.validate("StringValue.MatchString", r.MatchString(string(c.val)))
 }
 
 // MatchRegexp verify that the string matches the regular expression re
 func (c *StringValue[T]) MatchRegexp(re *regexp.Regexp) *StringValue[T] {
-	return c.validate("StringValue.MatchRegexp", re.MatchString(string(c.val)), nil)
+	return c.validate("StringValue.MatchRegexp", re.MatchString(string(c.val)))
 }
 
 // IPv4 verify that the string is formatted for IPv4.
 func (c *StringValue[T]) IPv4() *StringValue[T] {
-	return c.validate("StringValue.IPv4", isIPv4(string(c.val)), nil)
+	return c.validate("StringValue.IPv4", isIPv4(string(c.val)))
 }
 
 // IPv6 verify that the string is formatted for IPv6.
 func (c *StringValue[T]) IPv6() *StringValue[T] {
-	return c.validate("StringValue.IPv6", isIPv6(string(c.val)), nil)
+	return c.validate("StringValue.IPv6", isIPv6(string(c.val)))
 }
 
 // URL verify that the string is formatted for URL.
 func (c *StringValue[T]) URL() *StringValue[T] {
-	return c.validate("StringValue.URL", isURL(string(c.val)), nil)
+	return c.validate("StringValue.URL", isURL(string(c.val)))
 }
 
 // Email verify that the string is formatted for email.
 func (c *StringValue[T]) Email() *StringValue[T] {
-	return c.validate("StringValue.Email", isEmail(string(c.val)), nil)
+	return c.validate("StringValue.Email", isEmail(string(c.val)))
 }
 
 // Alphabet Check if the string consists of letters
 func (c *StringValue[T]) Alphabet() *StringValue[T] {
-	return c.validate("StringValue.Alphabet", reAlphabet.MatchString(string(c.val)), nil)
+	return c.validate("StringValue.Alphabet", reAlphabet.MatchString(string(c.val)))
 }
 
 // Numeric Check if the string consists of numbers
 func (c *StringValue[T]) Numeric() *StringValue[T] {
-	return c.validate("StringValue.Numeric", reNumeric.MatchString(string(c.val)), nil)
+	return c.validate("StringValue.Numeric", reNumeric.MatchString(string(c.val)))
 }
 
 // AlphabetNumeric Check the string consists of letters and numbers.
 func (c *StringValue[T]) AlphabetNumeric() *StringValue[T] {
-	return c.validate("StringValue.AlphabetNumeric", reAlphabetNumeric.MatchString(string(c.val)), nil)
+	return c.validate("StringValue.AlphabetNumeric", reAlphabetNumeric.MatchString(string(c.val)))
 }
 
 // Base64 verify that the string is formatted for base64.
 func (c *StringValue[T]) Base64() *StringValue[T] {
 	_, err := base64.StdEncoding.DecodeString(string(c.val))
-	return c.validate("StringValue.Base64", err == nil, nil)
+	return c.validate("StringValue.Base64", err == nil)
 }
 
 // Hex verify that the string is formatted for hex.
 func (c *StringValue[T]) Hex() *StringValue[T] {
 	_, err := hex.DecodeString(string(c.val))
-	return c.validate("StringValue.Hex", err == nil, nil)
+	return c.validate("StringValue.Hex", err == nil)
 }
 
 // Lowercase verify the string consists of lowercase letters.
 func (c *StringValue[T]) Lowercase() *StringValue[T] {
-	return c.validate("StringValue.Lowercase", string(c.val) == strings.ToLower(string(c.val)), nil)
+	return c.validate("StringValue.Lowercase", string(c.val) == strings.ToLower(string(c.val)))
 }
 
 // Uppercase verify the string consists of uppercase letters.
 func (c *StringValue[T]) Uppercase() *StringValue[T] {
-	return c.validate("StringValue.Uppercase", string(c.val) == strings.ToUpper(string(c.val)), nil)
+	return c.validate("StringValue.Uppercase", string(c.val) == strings.ToUpper(string(c.val)))
+}
+
+// NoLeadingTrailingSpace reject the string with leading or trailing white space, it checks the value before trimming.
+func (c *StringValue[T]) NoLeadingTrailingSpace() *StringValue[T] {
+	return c.validate("StringValue.NoLeadingTrailingSpace", strings.TrimSpace(string(c.raw)) == string(c.raw))
+}
+
+// EqField check the string is equal to the other field, such as a password confirmation
+func (c *StringValue[T]) EqField(other string, v T) *StringValue[T] {
+	c.checkField("StringValue.EqField", c.val == c.normalize(v), other)
+	return c
+}
+
+// NeField check the string is not equal to the other field
+func (c *StringValue[T]) NeField(other string, v T) *StringValue[T] {
+	c.checkField("StringValue.NeField", c.val != c.normalize(v), other)
+	return c
+}
+
+// AnyOf check that the string passes at least one of the rule sets, such as IPv4 or IPv6.
+// The message lists the first broken rule of every set, empty sets and sets whose rules are all skipped are ignored.
+func (c *StringValue[T]) AnyOf(fs ...func(s *StringValue[T])) *StringValue[T] {
+	if c.done() {
+		return c.validate("StringValue.AnyOf", true)
+	}
+	var rules subRules
+	for _, f := range fs {
+		var v = c.clone()
+		f(v)
+		if v.invalid != nil {
+			c.fail(v.invalid)
+			return c
+		}
+		if !v.ran {
+			continue
+		}
+		if !v.mark {
+			return c.validate("StringValue.AnyOf", true, c.passed(f))
+		}
+		rules = append(rules, v.locConf)
+	}
+	if len(rules) == 0 {
+		c.ignore()
+		return c
+	}
+	return c.validate("StringValue.AnyOf", false, rules)
+}
+
+// AllOf check that the string passes all the rule sets, the message lists every broken rule.
+// It is ignored when the sets are empty or all their rules are skipped.
+func (c *StringValue[T]) AllOf(fs ...func(s *StringValue[T])) *StringValue[T] {
+	if c.done() {
+		return c.validate("StringValue.AllOf", true)
+	}
+	var v = c.clone()
+	v.all = true
+	for _, f := range fs {
+		f(v)
+	}
+	if v.invalid != nil {
+		c.fail(v.invalid)
+		return c
+	}
+	if !v.ran {
+		c.ignore()
+		return c
+	}
+	if !v.mark {
+		return c.validate("StringValue.AllOf", true, c.passed(fs...))
+	}
+	return c.validate("StringValue.AllOf", false, subRules(v.locConfs))
+}
+
+// Not check that the string breaks the rule set, the message lists the rules that passed.
+// A rule that cannot be checked, such as an invalid regular expression, breaks Not as well.
+// It is ignored when the set is empty or all its rules are skipped.
+func (c *StringValue[T]) Not(f func(s *StringValue[T])) *StringValue[T] {
+	if c.done() {
+		return c.validate("StringValue.Not", true)
+	}
+	var v = c.clone()
+	f(v)
+	if v.invalid != nil {
+		c.fail(v.invalid)
+		return c
+	}
+	if !v.ran {
+		c.ignore()
+		return c
+	}
+	if v.mark {
+		return c.validate("StringValue.Not", true, subRules(v.locConfs))
+	}
+	v = c.clone()
+	v.invert = true
+	return c.validate("StringValue.Not", false, v.passed(f))
+}
+
+// passed 反转校验结果, 收集通过的规则, 用于 Not 的信息; 不在 Not 中时不需要
+func (c *StringValue[T]) passed(fs ...func(s *StringValue[T])) subRules {
+	if !c.invert {
+		return nil
+	}
+	var v = c.clone()
+	v.all = true
+	v.invert = true
+	for _, f := range fs {
+		f(v)
+	}
+	return subRules(v.locConfs)
+}
+
+// clone 复制键和值, 用于组合规则
+func (c *StringValue[T]) clone() *StringValue[T] {
+	return &StringValue[T]{core: core{key: c.key, conf: c.conf}, val: c.val, raw: c.raw, trim: c.trim}
+}
+
+// normalize 与字段值做相同的处理, 用于比较其他字段
+func (c *StringValue[T]) normalize(v T) T {
+	if c.trim {
+		return T(strings.TrimSpace(string(v)))
+	}
+	return v
 }
 
 // Customize customized data validation
 // @layout error message
 // @f check function
 func (c *StringValue[T]) Customize(messageId string, f func(T) bool) *StringValue[T] {
-	return c.validate(messageId, f(c.val), nil)
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
 }
@@ -3,6 +3,7 @@ package validator
 import (
 	"encoding/base64"
 	"encoding/hex"
+	"errors"
 	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
@@ -185,3 +186,266 @@ func TestStringValue_Between(t *testing.T) {
 	assert.Nil(t, String("age", "4").Between("3", "5").Err())
 	assert.Error(t, String("age", "5").Between("3", "5").Err())
 }
+
+func TestStringValue_All(t *testing.T) {
+	t.Run("", func(t *testing.T) {
+		var err = String("pwd", "pwd").All().Gte(8).Uppercase().Numeric().Err()
+		var list Errors
+		assert.True(t, errors.As(err, &list))
+		assert.Equal(t, 3, len(list))
+		assert.Equal(t, "pwd length must be greater than or equal to 8; "+
+			"pwd must consist of uppercase letters only; "+
+			"pwd must consist of numbers only", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = String("pwd", "pwd").Gte(8).Uppercase().Numeric().Err()
+		var fe *FieldError
+		assert.True(t, errors.As(err, &fe))
+		assert.Equal(t, "StringValue.Gte", fe.MessageID)
+	})
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).ValidateAll(
+			String("pwd", "PWD").All().Gte(8).MatchString(`^\d+$`).Uppercase(),
+			Ordered("age", 1).Gte(18),
+		)
+		assert.Equal(t, 3, len(err.(Errors)))
+	})
+
+	t.Run("", func(t *testing.T) {
+		var value = String("pwd", "pwd").All().Gte(8).Uppercase()
+		value.locConfs[1].MessageID = "oh"
+		assert.Error(t, value.Err())
+		var list Errors
+		assert.False(t, errors.As(value.Err(), &list))
+	})
+
+	t.Run("", func(t *testing.T) {
+		assert.Nil(t, String("pwd", "PWD123456").All().Gte(8).AlphabetNumeric().Err())
+	})
+}
+
+func TestStringValue_When(t *testing.T) {
+	assert.Nil(t, String("VAT", "").When(false).Required().Err())
+	assert.Error(t, String("VAT", "").When(true).Required().Err())
+	assert.Nil(t, String("VAT", "").Unless(true).Required().Err())
+	assert.Error(t, String("VAT", "").Unless(false).Required().Err())
+	assert.Error(t, String("VAT", "").When(false).Required().When(true).Gt(1).Err())
+	assert.Nil(t, String("VAT", "").When(false).MatchString(`^\d+$`).Customize("Customize", func(s string) bool { return false }).Err())
+}
+
+func TestStringValue_RequiredIf(t *testing.T) {
+	assert.Nil(t, String("VAT", "").RequiredIf("type", "person", "company").Err())
+	assert.Nil(t, String("VAT", "123").RequiredIf("type", "company", "company").Err())
+	assert.Equal(t, "VAT is required when type is company", String("VAT", "").RequiredIf("type", "company", "company").Err().Error())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(String("VAT", "").RequiredIf("type", "company", "company"))
+		assert.Equal(t, "type 为 company 时 VAT 不能为空", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		type Kind string
+		assert.Error(t, String("name", "").RequiredIf("type", Kind("person"), "person").Err())
+		assert.Nil(t, String("name", "").RequiredIf("type", Kind("company"), "person").Err())
+		assert.Nil(t, String("name", "").RequiredIf("type", 1, "\x01").Err())
+	})
+}
+
+func TestStringValue_RequiredWith(t *testing.T) {
+	assert.Nil(t, String("city", "").RequiredWith("street", "").Err())
+	assert.Nil(t, String("city", "Paris").RequiredWith("street", "Rue").Err())
+	assert.Equal(t, "city is required when street is present", String("city", "").RequiredWith("street", "Rue").Err().Error())
+
+	assert.Nil(t, String("email", "").RequiredWithout("phone", "123").Err())
+	assert.Equal(t, "email is required when phone is absent", String("email", "").RequiredWithout("phone", "").Err().Error())
+
+	assert.Nil(t, String("coupon", "A").ExcludedWith("discount", 0).Err())
+	assert.Nil(t, String("coupon", "").ExcludedWith("discount", 10).Err())
+	assert.Equal(t, "coupon must be empty when discount is present", String("coupon", "A").ExcludedWith("discount", 10).Err().Error())
+}
+
+func TestStringValue_Optional(t *testing.T) {
+	assert.Nil(t, String("website", "").Optional().URL().Err())
+	assert.Nil(t, String("website", " ").Optional().Gte(3).Err())
+	assert.Error(t, String("website", "aha").Optional().URL().Err())
+	assert.Nil(t, String("website", "").Optional().When(true).MatchString(`^\d+$`).Err())
+}
+
+func TestStringValue_Field(t *testing.T) {
+	assert.Nil(t, String("Confirm", "secret").EqField("Password", " secret").Err())
+	assert.Equal(t, "Confirm must equal Password", String("Confirm", "secret").EqField("Password", "Secret").Err().Error())
+	assert.Nil(t, String("NewPassword", "a").NeField("OldPassword", "b").Err())
+	assert.Equal(t, "NewPassword must not equal OldPassword", String("NewPassword", "a").NeField("OldPassword", "a").Err().Error())
+}
+
+func TestStringValue_AnyOf(t *testing.T) {
+	var ip = func(v string) *StringValue[string] {
+		return String("ip", v).AnyOf(
+			func(s *StringValue[string]) { s.IPv4() },
+			func(s *StringValue[string]) { s.IPv6() },
+		)
+	}
+	assert.Nil(t, ip("127.0.0.1").Err())
+	assert.Nil(t, ip("::1").Err())
+	assert.Equal(t, "ip must satisfy one of (ip must be in IPv4 format; ip must be in IPv6 format)", ip("aha").Err().Error())
+	assert.Nil(t, String("ip", "").Optional().AnyOf(func(s *StringValue[string]) { s.IPv4() }).Err())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(ip("aha"))
+		assert.Equal(t, "ip 须满足其中一项(ip 须符合IPv4格式; ip 须符合IPv6格式)", err.Error())
+	})
+}
+
+func TestStringValue_AllOf(t *testing.T) {
+	var f = func(s *StringValue[string]) { s.Gte(3).Lowercase() }
+	assert.Nil(t, String("name", "aha").AllOf(f).Err())
+	assert.Equal(t, "name must satisfy all of (name length must be greater than or equal to 3; name must consist of lowercase letters only)", String("name", "A").AllOf(f).Err().Error())
+
+	t.Run("nested", func(t *testing.T) {
+		var err = String("name", "A").AnyOf(
+			func(s *StringValue[string]) { s.AllOf(f) },
+			func(s *StringValue[string]) { s.Numeric() },
+		).Err()
+		assert.Equal(t, "name must satisfy one of (name must satisfy all of (name length must be greater than or equal to 3; name must consist of lowercase letters only); name must consist of numbers only)", err.Error())
+	})
+}
+
+func TestStringValue_Not(t *testing.T) {
+	var f = func(s *StringValue[string]) { s.Lowercase() }
+	assert.Nil(t, String("code", "ABC").Not(f).Err())
+	assert.Equal(t, "code must not satisfy (code must consist of lowercase letters only)", String("code", "abc").Not(f).Err().Error())
+	assert.Nil(t, String("code", "abc").Not(func(s *StringValue[string]) { s.Lowercase().Numeric() }).Err())
+	assert.Equal(t, "StringValue.ParseRegexp", String("code", "abc").Not(func(s *StringValue[string]) { s.MatchString("(") }).Err().(*FieldError).MessageID)
+	assert.Error(t, String("code", "abc").Not(func(s *StringValue[string]) {
+		s.AnyOf(func(s *StringValue[string]) { s.MatchString("(") }, func(s *StringValue[string]) { s.Numeric() })
+	}).Err())
+	assert.Equal(t, "code must not satisfy (code must satisfy one of (code must consist of lowercase letters only))", String("code", "abc").Not(func(s *StringValue[string]) {
+		s.AnyOf(func(s *StringValue[string]) { s.Numeric() }, f)
+	}).Err().Error())
+
+	t.Run("", func(t *testing.T) {
+		var err = String("code", "abc").All().Required().Not(f).Not(f).Err()
+		assert.Equal(t, 2, len(err.(Errors)))
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		var skipped = func(s *StringValue[string]) { s.When(false).IPv4() }
+		assert.Nil(t, String("x", "abc").AnyOf().Err())
+		assert.Nil(t, String("x", "abc").AnyOf(skipped).Err())
+		assert.Equal(t, "x must satisfy one of (x must be in IPv4 format)", String("x", "abc").AnyOf(skipped, func(s *StringValue[string]) { s.IPv4() }).Err().Error())
+		assert.Nil(t, String("x", "abc").AllOf().Err())
+		assert.Nil(t, String("x", "abc").AllOf(skipped).Err())
+		assert.Nil(t, String("x", "abc").Not(func(s *StringValue[string]) {}).Err())
+		assert.Nil(t, String("x", "abc").Not(skipped).Err())
+		assert.Nil(t, String("x", "abc").Not(func(s *StringValue[string]) { s.AnyOf() }).Err())
+		assert.Equal(t, "x cannot be empty", String("x", "").Required().AnyOf().Msg("aha").Err().Error())
+	})
+}
+
+func TestStringRaw(t *testing.T) {
+	assert.Error(t, StringRaw("name", " ").Eq(0).Err())
+	assert.Nil(t, StringRaw("name", " ").Required().Eq(1).Err())
+	assert.Nil(t, String("name", " ").Eq(0).Err())
+	assert.Error(t, StringRaw("name", " 1").MatchString(`^\d+$`).Err())
+	assert.Nil(t, StringRaw("Confirm", "a ").EqField("Password", "a ").Err())
+	assert.Error(t, StringRaw("Confirm", "a").EqField("Password", "a ").Err())
+	assert.Nil(t, String("Confirm", "a").EqField("Password", "a ").Err())
+}
+
+func TestStringValue_NoLeadingTrailingSpace(t *testing.T) {
+	assert.Nil(t, String("name", "a b").NoLeadingTrailingSpace().Err())
+	assert.Nil(t, StringRaw("name", "").NoLeadingTrailingSpace().Err())
+	assert.Equal(t, "name must not have leading or trailing white space", String("name", "aha ").NoLeadingTrailingSpace().Err().Error())
+	assert.Error(t, StringRaw("name", "\taha").NoLeadingTrailingSpace().Err())
+	assert.Error(t, String("name", " ").Not(func(s *StringValue[string]) { s.Required() }).NoLeadingTrailingSpace().All().Err())
+}
+
+func TestStringValue_Transform(t *testing.T) {
+	assert.Equal(t, "aha@example.com", String("email", " AHA@Example.com ").ToLower().Email().Value())
+	assert.Equal(t, "AHA", String("name", "aha").ToUpper().Value())
+	assert.Equal(t, "a b c", String("name", " a \t b\n\nc ").CollapseSpace().Value())
+	assert.Equal(t, "ab", String("name", "a\x00b\r").StripControl().Value())
+	assert.Equal(t, "é", String("name", "é").NFC().Value())
+	assert.Equal(t, "ABC123", String("code", "ＡＢＣ１２３").NFKC().Value())
+	assert.Equal(t, "x", String("name", "aha").Transform(func(s string) string { return "x" }).Value())
+
+	t.Run("", func(t *testing.T) {
+		assert.Nil(t, String("code", "ＡＢＣ").NFKC().Alphabet().Eq(3).Err())
+		assert.Error(t, String("code", "ＡＢＣ").Alphabet().Err())
+		assert.Equal(t, "abc", String("code", "ABC").Lowercase().ToLower().Err().(*FieldError).Value)
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		var name = " Aha "
+		assert.Equal(t, "Aha", StringRef("name", &name).When(false).ToLower().Value())
+		assert.Equal(t, "Aha", name)
+		assert.Equal(t, "aha", StringRef("name", &name).Unless(true).ToUpper().Unless(false).ToLower().Value())
+		assert.Equal(t, "aha", name)
+	})
+}
+
+func TestStringValue_RuneLen(t *testing.T) {
+	var name = "欧阳娜娜娜娜娜娜娜娜"
+	assert.Error(t, String("name", name).Lte(20).Err())
+	assert.Nil(t, String("name", name).RuneLenLte(10).RuneLenGte(10).RuneLenEq(10).RuneLenGt(9).RuneLenLt(11).Err())
+	assert.Error(t, String("name", name).RuneLenEq(9).Err())
+	assert.Error(t, String("name", name).RuneLenGt(10).Err())
+	assert.Error(t, String("name", name).RuneLenGte(11).Err())
+	assert.Error(t, String("name", name).RuneLenLt(10).Err())
+	assert.Equal(t, "name must be at most 9 characters", String("name", name).RuneLenLte(9).Err().Error())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(String("name", name).RuneLenLte(9))
+		assert.Equal(t, "name 最多9个字符", err.Error())
+	})
+}
+
+func TestStringValue_CharLen(t *testing.T) {
+	var s = "👍🏽é🇨🇳"
+	assert.Nil(t, String("emoji", s).CharLenEq(3).CharLenGt(2).CharLenGte(3).CharLenLt(4).CharLenLte(3).Err())
+	assert.Error(t, String("emoji", s).RuneLenEq(3).Err())
+	assert.Error(t, String("emoji", s).CharLenEq(4).Err())
+	assert.Error(t, String("emoji", s).CharLenGt(3).Err())
+	assert.Error(t, String("emoji", s).CharLenGte(4).Err())
+	assert.Error(t, String("emoji", s).CharLenLt(3).Err())
+	assert.Equal(t, "emoji must be at least 4 characters", String("emoji", s).CharLenGte(4).Err().Error())
+}
+
+func TestStringValue_Default(t *testing.T) {
+	var lang, sort = "", " asc "
+	assert.Nil(t, StringRef("lang", &lang).Default("en-US").In("en-US", "zh-CN").Err())
+	assert.Nil(t, StringRef("sort", &sort).Default("desc").In("asc", "desc").Err())
+	assert.Equal(t, "en-US", lang)
+	assert.Equal(t, "asc", sort)
+
+	var name = " Aha "
+	assert.Equal(t, "aha", StringRef("name", &name).ToLower().Value())
+	assert.Equal(t, "aha", name)
+
+	assert.Equal(t, "x", String("name", " ").Default(" x ").Value())
+	assert.Error(t, String("name", "").Default(" x ").NoLeadingTrailingSpace().Err())
+	assert.Equal(t, " x ", StringRaw("name", "").Default(" x ").Value())
+
+	t.Run("when", func(t *testing.T) {
+		var lang, size, ids = "", 0, []int(nil)
+		assert.Equal(t, "", StringRef("lang", &lang).When(false).Default("en-US").Value())
+		assert.Equal(t, 0, OrderedRef("size", &size).Unless(true).Default(20).Value())
+		assert.Nil(t, SliceRef("ids", &ids).When(false).Default([]int{1}).Value())
+		assert.Equal(t, "", lang)
+		assert.Equal(t, 0, size)
+		assert.Nil(t, ids)
+		assert.Equal(t, "en-US", StringRef("lang", &lang).When(false).When(true).Default("en-US").Value())
+		assert.Equal(t, "en-US", lang)
+	})
+
+	t.Run("optional", func(t *testing.T) {
+		assert.Error(t, String("lang", "").Optional().Default("x").In("en-US", "zh-CN").Err())
+		assert.Nil(t, String("lang", "").Optional().Default("zh-CN").In("en-US", "zh-CN").Err())
+		assert.Nil(t, String("lang", " x ").Optional().Transform(func(string) string { return "" }).Required().Err())
+		assert.Error(t, Ordered("size", 0).Optional().Default(200).Lte(100).Err())
+		assert.Error(t, Slice[int]("ids", nil).Optional().Default([]int{2, 2}).Unique().Err())
+		assert.Nil(t, Slice[int]("ids", nil).Optional().Default(nil).Required().Err())
+	})
+}
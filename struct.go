@@ -0,0 +1,470 @@
+package validator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"github.com/xray-family/validator/internal/tags"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ErrInvalidTag the validate tag of a struct field cannot be applied
+var ErrInvalidTag = errors.New("validator: invalid validate tag")
+
+const tagName = "validate"
+
+type StructValue struct {
+	err    error
+	all    bool
+	skip   bool
+	conf   *config
+	values []Valuer
+}
+
+// Struct validates the exported fields of v according to their `validate:"required,gte=18"` tags.
+// Rule names are the method names of the builders, the field key comes from the json tag.
+// Struct fields, pointers to struct and slices of struct are validated recursively,
+// and their errors are reported as address.city or Items[2].Name.
+func Struct(v any) *StructValue {
+	var c = &StructValue{conf: defaultConf()}
+	var rv = reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		c.err = fmt.Errorf("%w: expected a struct, got %s", ErrInvalidTag, rv.Kind())
+		return c
+	}
+	c.values, c.err = structValues(rv)
+	return c
+}
+
+func (c *StructValue) setConf(conf *config) {
+	c.conf = conf
+}
+
+func (c *StructValue) setPrefix(prefix string) {
+	for _, item := range c.values {
+		item.setPrefix(prefix)
+	}
+}
+
+// All keep checking the fields after the first failure, Err reports every failed field.
+func (c *StructValue) All() *StructValue {
+	c.all = true
+	return c
+}
+
+// When validates the fields only if cond is true.
+func (c *StructValue) When(cond bool) *StructValue {
+	c.skip = !cond
+	return c
+}
+
+// Unless validates the fields only if cond is false.
+func (c *StructValue) Unless(cond bool) *StructValue {
+	c.skip = cond
+	return c
+}
+
+// Err get error
+func (c *StructValue) Err() error {
+	if c.skip {
+		return nil
+	}
+	if c.err != nil {
+		return c.err
+	}
+	c.err = validateValues(c.conf, c.values, c.all || c.conf.all)
+	return c.err
+}
+
+// structValues 根据标签生成结构体字段的校验器
+func structValues(rv reflect.Value) ([]Valuer, error) {
+	var values []Valuer
+	var rt = rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		var field = rt.Field(i)
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			list, err := structValues(rv.Field(i))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, list...)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		var tag = field.Tag.Get(tagName)
+		if tag == "-" {
+			continue
+		}
+		var rules []tags.Rule
+		if tag != "" {
+			list, err := tags.Parse(tag)
+			if err != nil {
+				return nil, fmt.Errorf("%w: field %s: %v", ErrInvalidTag, field.Name, err)
+			}
+			rules = list
+		}
+		list, err := fieldValues(fieldKey(field), rv.Field(i), rules)
+		if errors.Is(err, ErrInvalidTag) {
+			return nil, err
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: field %s: %v", ErrInvalidTag, field.Name, err)
+		}
+		values = append(values, list...)
+	}
+	return values, nil
+}
+
+// fieldKey 字段名优先取自json标签
+func fieldKey(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// fieldValues 生成字段的校验器, 没有规则的结构体及其切片会被递归校验
+func fieldValues(key string, rv reflect.Value, rules []tags.Rule) ([]Valuer, error) {
+	if len(rules) == 0 && !hasStruct(rv.Type()) {
+		return nil, nil
+	}
+	switch rv.Kind() {
+	case reflect.Struct:
+		if len(rules) > 0 {
+			return nil, errors.New("rules are not supported on struct")
+		}
+		list, err := structValues(rv)
+		if err != nil || len(list) == 0 {
+			return nil, err
+		}
+		return []Valuer{Nested(key, list...)}, nil
+	case reflect.Pointer:
+		return pointerValues(key, rv, rules)
+	case reflect.String:
+		value, err := stringValue(key, rv.String(), rules)
+		return []Valuer{value}, err
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := orderedValue(key, rv.Int(), rules, parseInt)
+		return []Valuer{value}, err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		value, err := orderedValue(key, rv.Uint(), rules, parseUint)
+		return []Valuer{value}, err
+	case reflect.Float32, reflect.Float64:
+		value, err := orderedValue(key, rv.Float(), rules, parseFloat)
+		return []Valuer{value}, err
+	case reflect.Slice, reflect.Array:
+		if hasStruct(rv.Type().Elem()) {
+			return elemValues(key, rv, rules)
+		}
+		value, err := sliceValue(key, rv, rules)
+		return []Valuer{value}, err
+	default:
+		return nil, fmt.Errorf("unsupported kind %s", rv.Kind())
+	}
+}
+
+// hasStruct 是否为结构体或者结构体的指针, 切片
+func hasStruct(rt reflect.Type) bool {
+	for rt.Kind() == reflect.Pointer || rt.Kind() == reflect.Slice || rt.Kind() == reflect.Array {
+		rt = rt.Elem()
+	}
+	return rt.Kind() == reflect.Struct
+}
+
+// elemValues 逐个校验结构体切片的元素, 字段路径形如 Items[2].Name
+func elemValues(key string, rv reflect.Value, rules []tags.Rule) ([]Valuer, error) {
+	var values []Valuer
+	if len(rules) > 0 {
+		value, err := sliceOfRules(key, rv, rules)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	for i := 0; i < rv.Len(); i++ {
+		list, err := fieldValues(key+"["+strconv.Itoa(i)+"]", rv.Index(i), nil)
+		if err != nil {
+			return nil, err
+		}
+		values = append(values, list...)
+	}
+	return values, nil
+}
+
+// pointerValues 指针先校验required, 非空时对指向的值校验其余规则
+func pointerValues(key string, rv reflect.Value, rules []tags.Rule) ([]Valuer, error) {
+	var values []Valuer
+	var rest = make([]tags.Rule, 0, len(rules))
+	for _, r := range rules {
+		if _, _, err := tags.Lookup(tags.Pointer, r); err != nil {
+			rest = append(rest, r)
+			continue
+		}
+		// 校验失败时指针必然为nil
+		var value = Any[any](key, nil).Customize("PointerValue.Required", func(any) bool {
+			return !rv.IsNil()
+		})
+		values = append(values, value)
+	}
+	if rv.IsNil() {
+		if len(rest) == 0 {
+			return values, nil
+		}
+		// 校验规则是否可用, 但不产生错误
+		_, err := fieldValues(key, reflect.New(rv.Type().Elem()).Elem(), rest)
+		return values, err
+	}
+	list, err := fieldValues(key, rv.Elem(), rest)
+	return append(values, list...), err
+}
+
+func stringValue(key string, v string, rules []tags.Rule) (Valuer, error) {
+	var value = String(key, v)
+	for _, r := range rules {
+		spec, args, err := tags.Lookup(tags.String, r)
+		if err != nil {
+			return nil, err
+		}
+		switch spec.Method {
+		case "Required":
+			value.Required()
+		case "Eq":
+			value.Eq(atoi(args[0]))
+		case "Gt":
+			value.Gt(atoi(args[0]))
+		case "Gte":
+			value.Gte(atoi(args[0]))
+		case "Lt":
+			value.Lt(atoi(args[0]))
+		case "Lte":
+			value.Lte(atoi(args[0]))
+		case "In":
+			value.In(args...)
+		case "Between":
+			value.Between(args[0], args[1])
+		case "MatchString":
+			value.MatchString(args[0])
+		case "IPv4":
+			value.IPv4()
+		case "IPv6":
+			value.IPv6()
+		case "URL":
+			value.URL()
+		case "Email":
+			value.Email()
+		case "Alphabet":
+			value.Alphabet()
+		case "Numeric":
+			value.Numeric()
+		case "AlphabetNumeric":
+			value.AlphabetNumeric()
+		case "Base64":
+			value.Base64()
+		case "Hex":
+			value.Hex()
+		case "Lowercase":
+			value.Lowercase()
+		case "Uppercase":
+			value.Uppercase()
+		case "NoLeadingTrailingSpace":
+			value.NoLeadingTrailingSpace()
+		case "RuneLenEq":
+			value.RuneLenEq(atoi(args[0]))
+		case "RuneLenGt":
+			value.RuneLenGt(atoi(args[0]))
+		case "RuneLenGte":
+			value.RuneLenGte(atoi(args[0]))
+		case "RuneLenLt":
+			value.RuneLenLt(atoi(args[0]))
+		case "RuneLenLte":
+			value.RuneLenLte(atoi(args[0]))
+		case "CharLenEq":
+			value.CharLenEq(atoi(args[0]))
+		case "CharLenGt":
+			value.CharLenGt(atoi(args[0]))
+		case "CharLenGte":
+			value.CharLenGte(atoi(args[0]))
+		case "CharLenLt":
+			value.CharLenLt(atoi(args[0]))
+		case "CharLenLte":
+			value.CharLenLte(atoi(args[0]))
+		}
+	}
+	return value, nil
+}
+
+func orderedValue[T cmp.Ordered](key string, v T, rules []tags.Rule, parse func(string) (T, error)) (Valuer, error) {
+	var value = Ordered(key, v)
+	for _, r := range rules {
+		spec, params, err := tags.Lookup(tags.Number, r)
+		if err != nil {
+			return nil, err
+		}
+		args, err := parseArgs(r, params, parse)
+		if err != nil {
+			return nil, err
+		}
+		switch spec.Method {
+		case "Required":
+			value.Required()
+		case "Gt":
+			value.Gt(args[0])
+		case "Gte":
+			value.Gte(args[0])
+		case "Lt":
+			value.Lt(args[0])
+		case "Lte":
+			value.Lte(args[0])
+		case "Between":
+			value.Between(args[0], args[1])
+		case "In":
+			value.In(args...)
+		}
+	}
+	return value, nil
+}
+
+func sliceValue(key string, rv reflect.Value, rules []tags.Rule) (Valuer, error) {
+	switch rv.Type().Elem().Kind() {
+	case reflect.String:
+		return sliceRules(key, convertSlice(rv, reflect.Value.String), rules, parseString)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return sliceRules(key, convertSlice(rv, reflect.Value.Int), rules, parseInt)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return sliceRules(key, convertSlice(rv, reflect.Value.Uint), rules, parseUint)
+	case reflect.Float32, reflect.Float64:
+		return sliceRules(key, convertSlice(rv, reflect.Value.Float), rules, parseFloat)
+	default:
+		return sliceOfRules(key, rv, rules)
+	}
+}
+
+// sliceOfRules 元素不是基础类型时, 仅支持长度规则及 unique
+func sliceOfRules(key string, rv reflect.Value, rules []tags.Rule) (Valuer, error) {
+	var value = SliceOf(key, convertSlice(rv, interfaceOf))
+	for _, r := range rules {
+		spec, params, err := tags.Lookup(tags.Slice, r)
+		if err != nil {
+			return nil, err
+		}
+		switch spec.Method {
+		case "Required":
+			value.Required()
+		case "Eq":
+			value.Eq(atoi(params[0]))
+		case "Gt":
+			value.Gt(atoi(params[0]))
+		case "Gte":
+			value.Gte(atoi(params[0]))
+		case "Lt":
+			value.Lt(atoi(params[0]))
+		case "Lte":
+			value.Lte(atoi(params[0]))
+		case "Unique":
+			value.Unique()
+		default:
+			return nil, fmt.Errorf("rule %s is not supported on slice of %s", r.Name, rv.Type().Elem().Kind())
+		}
+	}
+	return value, nil
+}
+
+func sliceRules[T cmp.Ordered](key string, v []T, rules []tags.Rule, parse func(string) (T, error)) (Valuer, error) {
+	var value = Slice(key, v)
+	for _, r := range rules {
+		spec, params, err := tags.Lookup(tags.Slice, r)
+		if err != nil {
+			return nil, err
+		}
+		switch spec.Method {
+		case "Required":
+			value.Required()
+		case "Eq":
+			value.Eq(atoi(params[0]))
+		case "Gt":
+			value.Gt(atoi(params[0]))
+		case "Gte":
+			value.Gte(atoi(params[0]))
+		case "Lt":
+			value.Lt(atoi(params[0]))
+		case "Lte":
+			value.Lte(atoi(params[0]))
+		case "Unique":
+			value.Unique()
+		case "Sorted":
+			value.Sorted()
+		case "SortedDesc":
+			value.SortedDesc()
+		default:
+			args, err := parseArgs(r, params, parse)
+			if err != nil {
+				return nil, err
+			}
+			switch spec.Method {
+			case "Contains":
+				value.Contains(args[0])
+			case "NotContains":
+				value.NotContains(args[0])
+			case "ContainsAll":
+				value.ContainsAll(args...)
+			case "ContainsAny":
+				value.ContainsAny(args...)
+			case "SubsetOf":
+				value.SubsetOf(args...)
+			}
+		}
+	}
+	return value, nil
+}
+
+// parseArgs 将规则参数转换为字段类型
+func parseArgs[T any](r tags.Rule, params []string, parse func(string) (T, error)) ([]T, error) {
+	var args = make([]T, 0, len(params))
+	for _, item := range params {
+		arg, err := parse(item)
+		if err != nil {
+			return nil, fmt.Errorf("rule %s: %v", r.Name, err)
+		}
+		args = append(args, arg)
+	}
+	return args, nil
+}
+
+// convertSlice 将切片或数组的元素转换为基础类型
+func convertSlice[T any](rv reflect.Value, f func(reflect.Value) T) []T {
+	var list = make([]T, rv.Len())
+	for i := range list {
+		list[i] = f(rv.Index(i))
+	}
+	return list
+}
+
+// interfaceOf 未导出的嵌入结构体中的值无法取出
+func interfaceOf(rv reflect.Value) any {
+	if rv.CanInterface() {
+		return rv.Interface()
+	}
+	return nil
+}
+
+// atoi 参数已由 tags.Lookup 校验
+func atoi(s string) int {
+	n, _ := strconv.Atoi(s)
+	return n
+}
+
+func parseString(s string) (string, error) { return s, nil }
+
+func parseInt(s string) (int64, error) { return strconv.ParseInt(s, 10, 64) }
+
+func parseUint(s string) (uint64, error) { return strconv.ParseUint(s, 10, 64) }
+
+func parseFloat(s string) (float64, error) { return strconv.ParseFloat(s, 64) }
@@ -0,0 +1,217 @@
+package validator
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type structReq struct {
+	Name    string   `json:"name" validate:"required,alphabet,lte=8"`
+	Age     int      `json:"age" validate:"gte=18"`
+	Score   float64  `json:"score,omitempty" validate:"between=0 100"`
+	Level   uint8    `validate:"in=1 2 3"`
+	Email   *string  `json:"email" validate:"email"`
+	Parent  *int     `json:"parent" validate:"required"`
+	Roles   []int    `json:"roles" validate:"required,lte=3,contains=1"`
+	Tags    []string `json:"-" validate:"gt=0,unique,subsetOf=a b c"`
+	Ignored string   `validate:"-"`
+	private string   `validate:"required"`
+}
+
+type structInvalid struct {
+	A bool `validate:"required"`
+}
+
+type structEmbed struct {
+	structReq
+	Phone string `json:"phone" validate:"numeric,eq=11"`
+}
+
+func TestStruct(t *testing.T) {
+	var newStruct = func() *structReq {
+		return &structReq{Parent: new(int), Name: "aha", Age: 18, Score: 60, Level: 1, Roles: []int{1}, Tags: []string{"a"}}
+	}
+
+	t.Run("", func(t *testing.T) {
+		assert.Nil(t, Struct(newStruct()).Err())
+		assert.Nil(t, Struct(*newStruct()).Err())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var req = newStruct()
+		req.Age = 3
+		assert.Equal(t, "age must be greater than or equal to 18", Struct(req).Err().Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var req = newStruct()
+		req.Name = "a1"
+		req.Level = 4
+		req.Roles = nil
+		req.Tags = nil
+		var err = Struct(req).All().Err()
+		var list = err.(Errors)
+		assert.Equal(t, 4, len(list))
+		assert.Equal(t, "name must consist of letters only", list[0].Error())
+		assert.Equal(t, "Level must be included in [1 2 3]", list[1].Error())
+		assert.Equal(t, "roles cannot be empty", list[2].Error())
+		assert.Equal(t, "Tags length must be greater than 0", list[3].Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var req = newStruct()
+		var email = "aha"
+		req.Email = &email
+		var err = NewValidator(newReq("zh-CN")).Validate(Struct(req))
+		assert.Equal(t, "email 须符合电子邮件地址格式", err.Error())
+		email = "aha@qq.com"
+		assert.Nil(t, Struct(req).Err())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var req = &structEmbed{structReq: *newStruct(), Phone: "123"}
+		var fe *FieldError
+		assert.True(t, errors.As(Struct(req).Err(), &fe))
+		assert.Equal(t, "phone", fe.Key)
+		assert.Equal(t, "StringValue.Eq", fe.MessageID)
+	})
+
+	t.Run("", func(t *testing.T) {
+		type Req struct {
+			Avatar *string `json:"avatar" validate:"required,url"`
+		}
+		var err = Struct(&Req{}).Err()
+		assert.Equal(t, "avatar cannot be empty", err.Error())
+	})
+}
+
+func TestStruct_MatchStringComma(t *testing.T) {
+	type Req struct {
+		Code string `json:"code" validate:"required,matchString=^[a-z]{2\\,4}$,lte=4"`
+	}
+	assert.Nil(t, Struct(&Req{Code: "abc"}).Err())
+	assert.Equal(t, "StringValue.MatchString", Struct(&Req{Code: "a"}).Err().(*FieldError).MessageID)
+
+	type Bad struct {
+		Code string `validate:"matchString=^[a-z]{2,4}$"`
+	}
+	assert.ErrorIs(t, Struct(&Bad{}).Err(), ErrInvalidTag)
+}
+
+func TestStruct_When(t *testing.T) {
+	var req = &structReq{Age: 3}
+	assert.Nil(t, Struct(req).When(false).Err())
+	assert.Error(t, Struct(req).When(true).Err())
+	assert.Error(t, Struct(req).Unless(false).Err())
+	assert.Nil(t, Struct(req).Unless(true).Err())
+	assert.Nil(t, NewValidator(newReq("en-US")).Validate(Nested("company", Struct(req).When(false))))
+}
+
+func TestStruct_InvalidTag(t *testing.T) {
+	var cases = []any{
+		1,
+		nil,
+		struct {
+			A string `validate:"gte"`
+		}{},
+		struct {
+			A string `validate:"required,,"`
+		}{},
+		struct {
+			A string `validate:"between=1"`
+		}{},
+		struct {
+			A string `validate:"contains=1"`
+		}{},
+		struct {
+			A int `validate:"email"`
+		}{},
+		struct {
+			A int `validate:"gte=a"`
+		}{},
+		struct {
+			A uint `validate:"between=1"`
+		}{},
+		struct {
+			A []int `validate:"gte=a"`
+		}{},
+		struct {
+			A []float64 `validate:"contains=a"`
+		}{},
+		struct {
+			A []uint `validate:"email"`
+		}{},
+		struct {
+			A []bool `validate:"contains=true"`
+		}{},
+		struct {
+			A []int `validate:"subsetOf=1 a"`
+		}{},
+		struct {
+			A bool `validate:"required"`
+		}{},
+		struct {
+			A *int `validate:"email"`
+		}{},
+		struct {
+			structInvalid
+		}{},
+	}
+	for _, item := range cases {
+		assert.True(t, errors.Is(Struct(item).Err(), ErrInvalidTag))
+	}
+}
+
+func TestStruct_SliceRules(t *testing.T) {
+	type Req struct {
+		IDs  []int    `json:"ids" validate:"unique,sorted,containsAll=1 2,containsAny=1 9,notContains=0"`
+		Desc []uint   `json:"desc" validate:"sortedDesc"`
+		Tags []string `json:"tags" validate:"subsetOf=a b,contains=a"`
+	}
+	assert.Nil(t, Struct(Req{IDs: []int{1, 2, 3}, Desc: []uint{3, 1}, Tags: []string{"a"}}).Err())
+	var list = ValidateAll(Struct(Req{IDs: []int{2, 1, 1}, Desc: []uint{1, 3}, Tags: []string{"c"}})).(Errors)
+	assert.Equal(t, 3, len(list))
+	assert.Equal(t, "ids must not contain duplicate elements, 1 is repeated", list[0].Error())
+	assert.Equal(t, "desc must be in descending order, 3 is out of order", list[1].Error())
+	assert.Equal(t, "tags element c must be included in [a b]", list[2].Error())
+}
+
+func TestStruct_SliceOf(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" validate:"required"`
+	}
+	type Req struct {
+		Flags  []bool   `json:"flags" validate:"required,lte=2"`
+		Items  []Item   `json:"items" validate:"required,unique"`
+		Chunks [][]byte `json:"chunks" validate:"unique"`
+	}
+	assert.Nil(t, Struct(Req{Flags: []bool{true}, Items: []Item{{Name: "a"}}}).Err())
+	var list = ValidateAll(Struct(Req{
+		Flags:  []bool{true, false, true},
+		Items:  []Item{{Name: "a"}, {Name: "a"}, {}},
+		Chunks: [][]byte{{1}, {1}},
+	})).(Errors)
+	assert.Equal(t, 4, len(list))
+	assert.Equal(t, "flags length must be less than or equal to 2", list[0].Error())
+	assert.Equal(t, "items must not contain duplicate elements, {a} is repeated", list[1].Error())
+	assert.Equal(t, "items[2].name cannot be empty", list[2].Error())
+	assert.Equal(t, "chunks must not contain duplicate elements, [1] is repeated", list[3].Error())
+}
+
+func TestStruct_NoLeadingTrailingSpace(t *testing.T) {
+	type req struct {
+		Name string `json:"name" validate:"required,noLeadingTrailingSpace"`
+	}
+	assert.Nil(t, Struct(req{Name: "aha"}).Err())
+	assert.Equal(t, "name must not have leading or trailing white space", Struct(req{Name: "aha "}).Err().Error())
+}
+
+func TestStruct_RuneLen(t *testing.T) {
+	type req struct {
+		Name string `json:"name" validate:"runeLenLte=4,charLenGte=2"`
+	}
+	assert.Nil(t, Struct(req{Name: "欧阳娜娜"}).Err())
+	assert.Equal(t, "name must be at most 4 characters", Struct(req{Name: "欧阳娜娜娜"}).Err().Error())
+	assert.Error(t, Struct(req{Name: "欧"}).Err())
+}
@@ -0,0 +1,170 @@
+package validator
+
+import (
+	"golang.org/x/text/language"
+	"time"
+)
+
+type TimeValue struct {
+	err error
+	core
+	val time.Time
+}
+
+func Time(k string, v time.Time) *TimeValue {
+	return &TimeValue{
+		core: newCore(k),
+		val:  v,
+	}
+}
+
+// All keep checking the rules after the first failure, Err reports every broken rule.
+// It must be called before the rules.
+func (c *TimeValue) All() *TimeValue {
+	c.all = true
+	return c
+}
+
+// Label sets the message id of the field name, the name replaces {{.Key}} in the messages.
+// Without a label the field name is looked up as Field.<key>, and the raw key is used when it is not found.
+func (c *TimeValue) Label(id string) *TimeValue {
+	c.label = id
+	return c
+}
+
+// Msg replaces the message of the previous rule if it failed, {{.Key}} and {{.Arg0}}... are available in text.
+func (c *TimeValue) Msg(text string) *TimeValue {
+	return c.Msgs(map[language.Tag]string{language.Und: text})
+}
+
+// Msgs replaces the message of the previous rule with the text of the best matching language,
+// the text of language.Und is used when no language matches.
+func (c *TimeValue) Msgs(texts map[language.Tag]string) *TimeValue {
+	c.msgs(texts)
+	return c
+}
+
+// MsgID replaces the message id of the previous rule if it failed.
+func (c *TimeValue) MsgID(id string) *TimeValue {
+	c.msgID(id)
+	return c
+}
+
+// When applies the following rules only if cond is true, until the next When or Unless.
+func (c *TimeValue) When(cond bool) *TimeValue {
+	c.skip = !cond
+	return c
+}
+
+// Unless applies the following rules only if cond is false, until the next When or Unless.
+func (c *TimeValue) Unless(cond bool) *TimeValue {
+	c.skip = cond
+	return c
+}
+
+// Optional skips the following rules when the value is empty, like omitempty.
+func (c *TimeValue) Optional() *TimeValue {
+	c.setOptional(c.val.IsZero())
+	return c
+}
+
+// RequiredIf the value is required when the other field equals want, want is converted to the type of the other field
+func (c *TimeValue) RequiredIf(other string, otherVal, want any) *TimeValue {
+	c.requiredIf(!c.val.IsZero(), other, otherVal, want)
+	return c
+}
+
+// RequiredWith the value is required when the other field is present
+func (c *TimeValue) RequiredWith(other string, otherVal any) *TimeValue {
+	c.requiredWith(!c.val.IsZero(), other, otherVal)
+	return c
+}
+
+// RequiredWithout the value is required when the other field is absent
+func (c *TimeValue) RequiredWithout(other string, otherVal any) *TimeValue {
+	c.requiredWithout(!c.val.IsZero(), other, otherVal)
+	return c
+}
+
+// ExcludedWith the value must be empty when the other field is present
+func (c *TimeValue) ExcludedWith(other string, otherVal any) *TimeValue {
+	c.excludedWith(!c.val.IsZero(), other, otherVal)
+	return c
+}
+
+func (c *TimeValue) validate(messageId string, ok bool, args ...any) *TimeValue {
+	c.check(messageId, ok, args...)
+	return c
+}
+
+// Err get error
+func (c *TimeValue) Err() error {
+	if !c.mark {
+		return nil
+	}
+	if c.err != nil {
+		return c.err
+	}
+	c.err = c.fieldError(c.val)
+	return c.err
+}
+
+// Required the time cannot be zero
+func (c *TimeValue) Required() *TimeValue {
+	return c.validate("TimeValue.Required", !c.val.IsZero())
+}
+
+// Before check the time is before t
+func (c *TimeValue) Before(t time.Time) *TimeValue {
+	return c.validate("TimeValue.Before", c.val.Before(t), formatTime(t))
+}
+
+// After check the time is after t
+func (c *TimeValue) After(t time.Time) *TimeValue {
+	return c.validate("TimeValue.After", c.val.After(t), formatTime(t))
+}
+
+// Between check that the time satisfies a <= x < b
+func (c *TimeValue) Between(a, b time.Time) *TimeValue {
+	return c.validate("TimeValue.Between", !c.val.Before(a) && c.val.Before(b), formatTime(a), formatTime(b))
+}
+
+// BeforeField check the time is before the other field
+func (c *TimeValue) BeforeField(other string, t time.Time) *TimeValue {
+	c.checkField("TimeValue.BeforeField", c.val.Before(t), other)
+	return c
+}
+
+// AfterField check the time is after the other field
+func (c *TimeValue) AfterField(other string, t time.Time) *TimeValue {
+	c.checkField("TimeValue.AfterField", c.val.After(t), other)
+	return c
+}
+
+// InFuture check the time is after now
+func (c *TimeValue) InFuture() *TimeValue {
+	return c.validate("TimeValue.InFuture", c.val.After(time.Now()))
+}
+
+// InPast check the time is before now
+func (c *TimeValue) InPast() *TimeValue {
+	return c.validate("TimeValue.InPast", c.val.Before(time.Now()))
+}
+
+// WithinLast check the time is between now-d and now
+func (c *TimeValue) WithinLast(d time.Duration) *TimeValue {
+	var now = time.Now()
+	return c.validate("TimeValue.WithinLast", !c.val.Before(now.Add(-d)) && !c.val.After(now), d.String())
+}
+
+func (c *TimeValue) Customize(messageId string, f func(time.Time) bool) *TimeValue {
+	if c.skipped() {
+		return c.validate(messageId, true)
+	}
+	return c.validate(messageId, f(c.val))
+}
+
+// formatTime 错误信息中的时间格式
+func formatTime(t time.Time) string {
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,87 @@
+package validator
+
+import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+	"testing"
+	"time"
+)
+
+func TestTimeValue_Required(t *testing.T) {
+	assert.Nil(t, Time("at", time.Now()).Required().Err())
+	assert.Equal(t, "at cannot be empty", Time("at", time.Time{}).Required().Err().Error())
+
+	t.Run("", func(t *testing.T) {
+		var err = NewValidator(newReq("zh-CN")).Validate(Time("at", time.Time{}).Required())
+		assert.Equal(t, "at 不能为空", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var value = Time("at", time.Time{}).Required()
+		value.locConf.MessageID = "aha"
+		assert.Error(t, value.Err())
+		assert.Error(t, value.Err())
+	})
+}
+
+func TestTimeValue_Range(t *testing.T) {
+	var a = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var b = time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, Time("at", a).Before(b).Err())
+	assert.Equal(t, "at must be before 2024-01-01T00:00:00Z", Time("at", b).Before(a).Err().Error())
+	assert.Nil(t, Time("at", b).After(a).Err())
+	assert.Equal(t, "at must be after 2024-02-01T00:00:00Z", Time("at", a).After(b).Err().Error())
+	assert.Nil(t, Time("at", a).Between(a, b).Err())
+	assert.Error(t, Time("at", b).Between(a, b).Err())
+	assert.Error(t, Time("at", a.Add(-time.Second)).Between(a, b).Err())
+}
+
+func TestTimeValue_Now(t *testing.T) {
+	var now = time.Now()
+	assert.Nil(t, Time("at", now.Add(time.Hour)).InFuture().Err())
+	assert.Equal(t, "at must be in the future", Time("at", now.Add(-time.Hour)).InFuture().Err().Error())
+	assert.Nil(t, Time("at", now.Add(-time.Hour)).InPast().Err())
+	assert.Equal(t, "at must be in the past", Time("at", now.Add(time.Hour)).InPast().Err().Error())
+	assert.Nil(t, Time("at", now.Add(-time.Hour)).WithinLast(2*time.Hour).Err())
+	assert.Equal(t, "at must be within the last 1h0m0s", Time("at", now.Add(-2*time.Hour)).WithinLast(time.Hour).Err().Error())
+	assert.Error(t, Time("at", now.Add(time.Hour)).WithinLast(time.Hour).Err())
+	assert.Equal(t, 2, len(Time("at", time.Time{}).All().Required().InFuture().Err().(Errors)))
+}
+
+func TestTimeValue_Customize(t *testing.T) {
+	_ = GetBundle().AddMessages(language.Make("en-US"), &i18n.Message{
+		ID:    "Weekday",
+		Other: "{{.Key}} must be a weekday",
+	})
+	var isWeekday = func(t time.Time) bool { return t.Weekday() != time.Saturday && t.Weekday() != time.Sunday }
+	var sat = time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "at must be a weekday", Time("at", sat).Customize("Weekday", isWeekday).Err().Error())
+	assert.Nil(t, Time("at", sat.Add(48*time.Hour)).Customize("Weekday", isWeekday).Err())
+	assert.Equal(t, "order.at must be a weekday", Nested("order", Time("at", sat).Customize("Weekday", isWeekday)).Err().Error())
+}
+
+func TestTimeValue_When(t *testing.T) {
+	assert.Nil(t, Time("at", time.Time{}).Unless(true).Required().Err())
+	assert.Error(t, Time("at", time.Time{}).RequiredWith("id", 1).Err())
+	assert.Error(t, Duration("ttl", 0).RequiredIf("mode", "cache", "cache").Err())
+	assert.Nil(t, Duration("ttl", time.Second).When(false).Gt(time.Minute).Err())
+	assert.Error(t, Pointer[int]("id", nil).RequiredWithout("name", "").Err())
+	assert.Nil(t, Pointer("id", new(int)).ExcludedWith("name", "").Err())
+	assert.Error(t, Any("id", 1).ExcludedWith("name", "aha").Err())
+	assert.Nil(t, Any("id", 0).ExcludedWith("name", "aha").Err())
+}
+
+func TestTimeValue_Optional(t *testing.T) {
+	assert.Nil(t, Time("at", time.Time{}).Optional().InPast().Err())
+	assert.Error(t, Time("at", time.Now().Add(time.Hour)).Optional().InPast().Err())
+	assert.Nil(t, Duration("ttl", 0).Optional().Gt(time.Second).Err())
+}
+
+func TestTimeValue_Field(t *testing.T) {
+	var start = time.Now()
+	assert.Nil(t, Time("End", start.Add(time.Hour)).AfterField("Start", start).Err())
+	assert.Nil(t, Time("Start", start).BeforeField("End", start.Add(time.Hour)).Err())
+	assert.Equal(t, "End must be after Start", Time("End", start).AfterField("Start", start).Err().Error())
+	assert.Equal(t, "Start must be before End", Time("Start", start).BeforeField("End", start).Err().Error())
+}
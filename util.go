@@ -1,9 +1,12 @@
 package validator
 
 import (
+	"github.com/nicksnyder/go-i18n/v2/i18n"
 	"net"
 	"net/mail"
 	"net/url"
+	"reflect"
+	"strconv"
 )
 
 // contains 是否包含
@@ -53,3 +56,58 @@ func isEmail(s string) bool {
 	}
 	return addr.Name == ""
 }
+
+// newLocalizeConfig 构建本地化配置, 参数依次命名为 Arg0, Arg1..., Key 在生成错误时填充
+func newLocalizeConfig(messageId string, args []any) *i18n.LocalizeConfig {
+	td := make(map[string]any, len(args)+1)
+	for i, v := range args {
+		td["Arg"+strconv.Itoa(i)] = v
+	}
+	return &i18n.LocalizeConfig{
+		MessageID:    messageId,
+		TemplateData: td,
+	}
+}
+
+// joinKey 拼接字段路径, 如 address.city, Items[2].Name
+func joinKey(prefix string, key string) string {
+	switch {
+	case prefix == "":
+		return key
+	case key == "":
+		return prefix
+	case key[0] == '[':
+		return prefix + key
+	default:
+		return prefix + "." + key
+	}
+}
+
+// isPresent 判断字段是否有值, nil、零值以及空的字符串、切片和映射视为缺失
+func isPresent(v any) bool {
+	if v == nil {
+		return false
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map, reflect.Array, reflect.Chan:
+		return rv.Len() > 0
+	default:
+		return !rv.IsZero()
+	}
+}
+
+// equalValue 比较其他字段的值, want 可转换为相同底层类型时先转换, 如 type Kind string 与 "person"
+func equalValue(v, want any) bool {
+	if reflect.DeepEqual(v, want) {
+		return true
+	}
+	if v == nil || want == nil {
+		return false
+	}
+	rv, rw := reflect.ValueOf(v), reflect.ValueOf(want)
+	if rv.Kind() != rw.Kind() || !rw.Type().ConvertibleTo(rv.Type()) {
+		return false
+	}
+	return reflect.DeepEqual(v, rw.Convert(rv.Type()).Interface())
+}
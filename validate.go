@@ -4,6 +4,7 @@ import "net/http"
 
 type Valuer interface {
 	setConf(conf *config)
+	setPrefix(prefix string)
 	Err() error
 }
 
@@ -11,18 +12,25 @@ type Validator struct {
 	conf *config
 }
 
+// NewValidator creates a validator from the default engine, see Engine.NewValidator.
 func NewValidator(r *http.Request, options ...Option) *Validator {
-	options = append(options, withLang(r), withInit())
-	var conf = new(config)
-	for _, f := range options {
-		f(conf)
-	}
-	return &Validator{conf: conf}
+	return Default().NewValidator(r, options...)
 }
 
 func (c *Validator) Validate(values ...Valuer) error {
+	return validateValues(c.conf, values, false)
+}
+
+// ValidateAll runs every Valuer and returns an Errors holding all failures in order.
+// Struct, Nested and Each also report every failed child.
+func (c *Validator) ValidateAll(values ...Valuer) error {
+	var conf = *c.conf
+	conf.all = true
+	return validateValues(&conf, values, true)
+}
+
+func Validate(values ...Valuer) error {
 	for _, item := range values {
-		item.setConf(c.conf)
 		if err := item.Err(); err != nil {
 			return err
 		}
@@ -30,11 +38,28 @@ func (c *Validator) Validate(values ...Valuer) error {
 	return nil
 }
 
-func Validate(values ...Valuer) error {
+// ValidateAll runs every Valuer and returns an Errors holding all failures in order.
+// Struct, Nested and Each also report every failed child.
+func ValidateAll(values ...Valuer) error {
+	var conf = *defaultConf()
+	conf.all = true
+	return validateValues(&conf, values, true)
+}
+
+// validateValues 依次校验, all为true时收集全部错误
+func validateValues(conf *config, values []Valuer, all bool) error {
+	var list Errors
 	for _, item := range values {
+		item.setConf(conf)
 		if err := item.Err(); err != nil {
-			return err
+			if !all {
+				return err
+			}
+			list = appendErrors(list, err)
 		}
 	}
-	return nil
+	if len(list) == 0 {
+		return nil
+	}
+	return list
 }
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -50,3 +51,35 @@ func TestValidate(t *testing.T) {
 		assert.Equal(t, err.Error(), "Age must be greater than or equal to 18")
 	})
 }
+
+func TestValidateAll(t *testing.T) {
+	t.Run("", func(t *testing.T) {
+		err := ValidateAll(
+			String("Name", "").Required(),
+			Ordered("Age", 3).Gte(18),
+			Slice("Roles", []int{1}).Required(),
+		)
+		var list Errors
+		assert.True(t, errors.As(err, &list))
+		assert.Equal(t, 2, len(list))
+		assert.Equal(t, "Name cannot be empty; Age must be greater than or equal to 18", err.Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		err := NewValidator(newReq("zh-CN")).ValidateAll(
+			String("Name", "").Required(),
+			Ordered("Age", 3).Gte(18),
+		)
+		var list = err.(Errors)
+		assert.Equal(t, "Name 不能为空", list[0].Error())
+		assert.Equal(t, "Age 须大于等于18", list[1].Error())
+	})
+
+	t.Run("", func(t *testing.T) {
+		var target = errors.New("target")
+		var err error = Errors{errors.New("a"), Errors{target}}
+		assert.True(t, errors.Is(err, target))
+		assert.Nil(t, ValidateAll(String("Name", "aha").Required()))
+		assert.Nil(t, NewValidator(nil).ValidateAll(String("Name", "aha").Required()))
+	})
+}